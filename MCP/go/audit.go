@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/auth"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// redacted replaces the values of secret arguments in the audit log.
+const redacted = "[REDACTED]"
+
+// secretArguments are redacted as a whole. Vault marks connection settings
+// as sensitive per connector, which the server cannot tell from the call, so
+// all of them are treated as secret.
+var secretArguments = map[string]bool{
+	"credentials": true,
+	"settings":    true,
+}
+
+// auditLog writes a JSON line for every tool call, for a record of who did
+// what through the agent. Lines are only ever appended.
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time       time.Time      `json:"time"`
+	Tool       string         `json:"tool"`
+	Identity   string         `json:"identity,omitempty"`
+	Session    string         `json:"session,omitempty"`
+	AppID      string         `json:"app_id,omitempty"`
+	ConsumerID string         `json:"consumer_id,omitempty"`
+	UnifiedAPI string         `json:"unified_api,omitempty"`
+	ServiceID  string         `json:"service_id,omitempty"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	DryRun     bool           `json:"dry_run,omitempty"` // nothing was sent to Vault
+	Outcome    string         `json:"outcome"`           // "success" or "error"
+	Error      string         `json:"error,omitempty"`
+	DurationMS float64        `json:"duration_ms"`
+}
+
+// openAuditLog opens the audit log named by dest: "stdout", "stderr" or the
+// path of a file to append to. It returns nil when dest is empty.
+func openAuditLog(dest string, stdio bool) (*auditLog, error) {
+	switch dest {
+	case "":
+		return nil, nil
+	case "stdout":
+		if stdio {
+			return nil, fmt.Errorf("AUDIT_LOG=stdout cannot be used in STDIO mode, where stdout carries the protocol")
+		}
+		return &auditLog{w: os.Stdout}, nil
+	case "stderr":
+		return &auditLog{w: os.Stderr}, nil
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening AUDIT_LOG: %w", err)
+	}
+	return &auditLog{w: f}, nil
+}
+
+// middleware records each call with the arguments it actually runs with, so
+// it has to run after the app and consumer IDs are defaulted.
+func (a *auditLog) middleware(catalog toolCatalog) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if a == nil {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			args, _ := request.Params.Arguments.(map[string]any)
+			entry := auditEntry{
+				Time:       start.UTC(),
+				Tool:       request.Params.Name,
+				AppID:      stringArgument(args, appIDArgument),
+				ConsumerID: auditedConsumer(catalog, request.Params.Name, args),
+				UnifiedAPI: stringArgument(args, "unified_api"),
+				ServiceID:  stringArgument(args, "service_id"),
+				Arguments:  redactArguments(args),
+				DryRun:     isDryRun(result),
+				Outcome:    "success",
+				DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if id := auth.FromContext(ctx); id != nil {
+				entry.Identity = id.Name
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				entry.Session = session.SessionID()
+			}
+			switch {
+			case err != nil:
+				entry.Outcome, entry.Error = "error", err.Error()
+			case result != nil && result.IsError:
+				entry.Outcome, entry.Error = "error", resultText(result)
+			}
+			a.write(entry)
+			return result, err
+		}
+	}
+}
+
+func (a *auditLog) write(entry auditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("Encoding audit entry failed: %v", err)
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		log.Printf("Writing audit log failed: %v", err)
+	}
+}
+
+// auditedConsumer returns the consumer a call to the named tool acts for:
+// the consumer argument the tool takes, not just any that was passed, so a
+// consumer header sent along with a consumer path argument is not logged.
+// Tools outside the catalog, such as set_current_consumer, name the consumer
+// in consumer_id.
+func auditedConsumer(catalog toolCatalog, name string, args map[string]any) string {
+	names := []string{"consumer_id"}
+	if tool, ok := catalog[name]; ok {
+		names = consumerArguments(tool.Definition)
+	}
+	for _, name := range names {
+		if consumerID := stringArgument(args, name); consumerID != "" {
+			return consumerID
+		}
+	}
+	return ""
+}
+
+func stringArgument(args map[string]any, name string) string {
+	v, _ := args[name].(string)
+	return v
+}
+
+// resultText returns the text of the first text content of result.
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
+
+// redactArguments returns a copy of args with the values of secret arguments
+// and fields replaced. Their keys are kept, so the log shows what was set.
+func redactArguments(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	out := make(map[string]any, len(args))
+	for name, v := range args {
+		if secretArguments[name] || vault.SecretName.MatchString(name) {
+			out[name] = redactAll(v)
+		} else {
+			out[name] = redactValue(v)
+		}
+	}
+	return out
+}
+
+// redactValue redacts the fields of v whose names hold secrets.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactArguments(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+// redactAll replaces every value in v, keeping the keys of objects.
+func redactAll(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for name, item := range v {
+			out[name] = redactAll(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactAll(item)
+		}
+		return out
+	case nil:
+		return nil
+	}
+	return redacted
+}
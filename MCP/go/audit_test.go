@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+)
+
+func TestAuditedConsumer(t *testing.T) {
+	vaultSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":[]}`))
+	}))
+	defer vaultSrv.Close()
+
+	cfg := &config.APIConfig{BaseURL: vaultSrv.URL, AppID: "sandbox-app", ConsumerID: "default-consumer"}
+	var current atomic.Pointer[config.APIConfig]
+	current.Store(cfg)
+	var buf bytes.Buffer
+	catalog := newToolCatalog(GetAll(cfg))
+	srv := createMCPServer(&current, catalog, newSessionConsumers(), newToolCalls(), newMetrics(), &auditLog{w: &buf})
+	ctx := srv.WithContext(context.Background(), newTestSession("s1"))
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		want string
+	}{
+		{name: "consumer in the path", tool: "delete_vault_consumers_consumer_id", args: map[string]any{"consumer_id": "victim"}, want: "victim"},
+		{name: "consumer header ignored by the tool", tool: "get_vault_consumers_consumer_id", args: map[string]any{"consumer_id": "victim", consumerIDArgument: "other"}, want: "victim"},
+		{name: "defaulted consumer header", tool: "get_vault_connections", args: map[string]any{}, want: "default-consumer"},
+		{name: "no consumer", tool: "get_vault_consumers", args: map[string]any{}},
+		{name: "current consumer", tool: setCurrentConsumerName, args: map[string]any{"consumer_id": "test-2"}, want: "test-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			var result mcp.CallToolResult
+			handleContext(t, srv, ctx, "tools/call", map[string]any{"name": tt.tool, "arguments": tt.args}, &result)
+			var entry auditEntry
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("audit line %q: %v", buf.String(), err)
+			}
+			if entry.ConsumerID != tt.want {
+				t.Errorf("consumer_id = %q, want %q", entry.ConsumerID, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "IDs are kept",
+			args: map[string]any{
+				"consumer_id":      "test-42",
+				consumerIDArgument: "test-42",
+				appIDArgument:      "sandbox-app",
+				"unified_api":      "crm",
+				"service_id":       "salesforce",
+				"enabled":          true,
+			},
+			want: map[string]any{
+				"consumer_id":      "test-42",
+				consumerIDArgument: "test-42",
+				appIDArgument:      "sandbox-app",
+				"unified_api":      "crm",
+				"service_id":       "salesforce",
+				"enabled":          true,
+			},
+		},
+		{
+			name: "nested credentials",
+			args: map[string]any{
+				"service_id": "salesforce",
+				"credentials": map[string]any{
+					"access_token":  "at-123",
+					"refresh_token": "rt-456",
+					"expires_in":    3600.0,
+					"scopes":        []any{"read", "write"},
+				},
+			},
+			want: map[string]any{
+				"service_id": "salesforce",
+				"credentials": map[string]any{
+					"access_token":  redacted,
+					"refresh_token": redacted,
+					"expires_in":    redacted,
+					"scopes":        []any{redacted, redacted},
+				},
+			},
+		},
+		{
+			name: "every settings value",
+			args: map[string]any{
+				"settings": map[string]any{
+					"instance_url": "https://acme.my.salesforce.com",
+					"subdomain":    "acme",
+					"sandbox":      false,
+					"unset":        nil,
+				},
+			},
+			want: map[string]any{
+				"settings": map[string]any{
+					"instance_url": redacted,
+					"subdomain":    redacted,
+					"sandbox":      redacted,
+					"unset":        nil,
+				},
+			},
+		},
+		{
+			name: "secret keys inside arrays",
+			args: map[string]any{
+				"configuration": []any{
+					map[string]any{"resource": "leads", "client_secret": "cs-1", "apiKey": "k-1"},
+					map[string]any{"resource": "contacts", "defaults": []any{map[string]any{"id": "owner", "Password": "pw"}}},
+				},
+			},
+			want: map[string]any{
+				"configuration": []any{
+					map[string]any{"resource": "leads", "client_secret": redacted, "apiKey": redacted},
+					map[string]any{"resource": "contacts", "defaults": []any{map[string]any{"id": "owner", "Password": redacted}}},
+				},
+			},
+		},
+		{
+			name: "secret top-level arguments",
+			args: map[string]any{"api_key": "k", "private_key": map[string]any{"pem": "-----BEGIN"}, "authorization": "Bearer x"},
+			want: map[string]any{"api_key": redacted, "private_key": map[string]any{"pem": redacted}, "authorization": redacted},
+		},
+		{
+			name: "OAuth callback",
+			args: map[string]any{"code": "oauth-code", "state": "eyJhbGciOiJIUzI1NiJ9.e30.sig"},
+			want: map[string]any{"code": redacted, "state": redacted},
+		},
+		{name: "no arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactArguments(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("redactArguments =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactArgumentsKeepsInput(t *testing.T) {
+	args := map[string]any{"credentials": map[string]any{"access_token": "at-123"}}
+	redactArguments(args)
+	if got := args["credentials"].(map[string]any)["access_token"]; got != "at-123" {
+		t.Errorf("input changed to %v", got)
+	}
+}
@@ -0,0 +1,201 @@
+// Package auth authenticates inbound HTTP clients of the MCP server and
+// carries the resulting identity through the request context.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+// ErrNoCredentials is returned by a Method when the request carries no
+// credentials of the kind it verifies, so that the next method can try.
+var ErrNoCredentials = errors.New("auth: no credentials")
+
+// Method verifies one kind of credential and returns the subject it
+// belongs to.
+type Method interface {
+	Authenticate(r *http.Request) (subject string, err error)
+}
+
+// Identity is an authenticated client and what it may do.
+type Identity struct {
+	Name string
+	config.Identity
+}
+
+// AllowsConsumer reports whether the identity may act for consumerID.
+func (id *Identity) AllowsConsumer(consumerID string) bool {
+	if len(id.ConsumerIDs) == 0 {
+		return true
+	}
+	for _, pattern := range id.ConsumerIDs {
+		if ok, _ := path.Match(pattern, consumerID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity carried by ctx, or nil for
+// unauthenticated requests.
+func FromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(contextKey{}).(*Identity)
+	return id
+}
+
+// Authenticator authenticates requests with the methods configured in the
+// auth section of a configuration file and maps subjects to identities.
+type Authenticator struct {
+	mu         sync.RWMutex
+	methods    []Method
+	identities map[string]config.Identity
+}
+
+// New returns an Authenticator for the auth section of file.
+func New(file *config.File) (*Authenticator, error) {
+	a := &Authenticator{}
+	if err := a.Update(file); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Update replaces the methods and identities, e.g. after the configuration
+// file was reloaded. On error the current ones stay in effect.
+func (a *Authenticator) Update(file *config.File) error {
+	if file == nil || !file.Auth.Enabled() {
+		return errors.New("auth: no authentication method is configured")
+	}
+	cfg := file.Auth
+	for name, id := range cfg.Identities {
+		if id.Profile == "" {
+			continue
+		}
+		if _, ok := file.Profiles[id.Profile]; !ok {
+			return fmt.Errorf("auth: identity %q uses unknown profile %q", name, id.Profile)
+		}
+	}
+	for _, pattern := range consumerPatterns(cfg.Identities) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("auth: invalid consumer_ids pattern %q: %w", pattern, err)
+		}
+	}
+
+	var methods []Method
+	if len(cfg.Tokens) > 0 {
+		tokens, err := newStaticTokens(cfg.Tokens, cfg.Identities)
+		if err != nil {
+			return err
+		}
+		methods = append(methods, tokens)
+	}
+	if cfg.HMAC != nil {
+		m, err := newHMACTokens(*cfg.HMAC)
+		if err != nil {
+			return err
+		}
+		methods = append(methods, m)
+	}
+	if cfg.JWT != nil {
+		m, err := newJWKSTokens(*cfg.JWT)
+		if err != nil {
+			return err
+		}
+		methods = append(methods, m)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.methods = methods
+	a.identities = cfg.Identities
+	return nil
+}
+
+func consumerPatterns(identities map[string]config.Identity) []string {
+	var patterns []string
+	for _, id := range identities {
+		patterns = append(patterns, id.ConsumerIDs...)
+	}
+	return patterns
+}
+
+// Authenticate returns the identity of the client that sent r.
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	err := ErrNoCredentials
+	for _, m := range a.methods {
+		subject, merr := m.Authenticate(r)
+		if errors.Is(merr, ErrNoCredentials) {
+			continue
+		}
+		if merr != nil {
+			err = merr
+			continue
+		}
+		id, ok := a.identities[subject]
+		if !ok {
+			return nil, &ForbiddenError{Subject: subject}
+		}
+		return &Identity{Name: subject, Identity: id}, nil
+	}
+	return nil, err
+}
+
+// ForbiddenError is returned for credentials that are valid but belong to a
+// subject without a configured identity.
+type ForbiddenError struct {
+	Subject string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("auth: %q has no identity configured", e.Subject)
+}
+
+// Handler authenticates each request before passing it to next, with the
+// identity in the request context. Requests without valid credentials are
+// answered with 401, authenticated subjects without an identity with 403.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := a.Authenticate(r)
+		var forbidden *ForbiddenError
+		switch {
+		case errors.As(err, &forbidden):
+			log.Printf("Rejected request from %s: %v", r.RemoteAddr, err)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		case err != nil:
+			log.Printf("Rejected request from %s: %v", r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
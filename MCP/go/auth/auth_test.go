@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+const (
+	testSecret   = "0123456789abcdef0123456789abcdef"
+	testIssuer   = "https://idp.example.com"
+	testAudience = "vault-mcp"
+)
+
+var testIdentities = map[string]config.Identity{
+	"ci":  {ConsumerIDs: []string{"test-*"}},
+	"ops": {},
+}
+
+type signingKey struct {
+	kid  string
+	priv ed25519.PrivateKey
+}
+
+func newSigningKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingKey{kid: kid, priv: priv}
+}
+
+// writeJWKS writes the public keys to a JWKS file and returns its path.
+func writeJWKS(t *testing.T, keys ...signingKey) string {
+	t.Helper()
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			Kid: k.kid,
+			X:   base64.RawURLEncoding.EncodeToString(k.priv.Public().(ed25519.PublicKey)),
+		})
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sign returns a JWT for claims, with a kid header unless kid is empty.
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// claims returns valid claims for sub, changed by the given overrides.
+func claims(sub string, overrides map[string]any) jwt.MapClaims {
+	c := jwt.MapClaims{
+		"sub": sub,
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, v := range overrides {
+		if v == nil {
+			delete(c, name)
+			continue
+		}
+		c[name] = v
+	}
+	return c
+}
+
+func newTestAuthenticator(t *testing.T, a config.Auth) *Authenticator {
+	t.Helper()
+	a.Identities = testIdentities
+	authenticator, err := New(&config.File{Auth: a})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return authenticator
+}
+
+func TestAuthenticatorHandler(t *testing.T) {
+	k1, k2 := newSigningKey(t, "k1"), newSigningKey(t, "k2")
+	hmacAuth := &config.HMACAuth{Secret: testSecret, Issuer: testIssuer, Audience: testAudience}
+	oneKey := &config.JWTAuth{JWKSFile: writeJWKS(t, k1), Issuer: testIssuer, Audience: testAudience}
+	twoKeys := &config.JWTAuth{JWKSFile: writeJWKS(t, k1, k2), Issuer: testIssuer, Audience: testAudience}
+	tokens := []config.StaticToken{
+		{Identity: "ci", Token: "ci-token"},
+		{Identity: "ops", Token: "ops-token"},
+	}
+
+	tests := []struct {
+		name     string
+		auth     config.Auth
+		token    string
+		status   int
+		identity string
+	}{
+		{name: "no credentials", auth: config.Auth{Tokens: tokens}, status: http.StatusUnauthorized},
+		{name: "first static token", auth: config.Auth{Tokens: tokens}, token: "ci-token", status: http.StatusOK, identity: "ci"},
+		{name: "last static token", auth: config.Auth{Tokens: tokens}, token: "ops-token", status: http.StatusOK, identity: "ops"},
+		{name: "unknown static token", auth: config.Auth{Tokens: tokens}, token: "ops-token2", status: http.StatusUnauthorized},
+		{
+			name:     "hmac token",
+			auth:     config.Auth{HMAC: hmacAuth},
+			token:    sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ci", nil)),
+			status:   http.StatusOK,
+			identity: "ci",
+		},
+		{
+			name:   "hmac token with another secret",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret+"x"), "", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "expired token",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ci", map[string]any{"exp": time.Now().Add(-time.Minute).Unix()})),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "token without expiry",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ci", map[string]any{"exp": nil})),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong issuer",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ci", map[string]any{"iss": "https://other.example.com"})),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong audience",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ci", map[string]any{"aud": "other"})),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown subject",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("intruder", nil)),
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "alg none",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "jwks token to hmac only",
+			auth:   config.Auth{HMAC: hmacAuth},
+			token:  sign(t, jwt.SigningMethodEdDSA, k1.priv, "k1", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "hmac token to jwks only",
+			auth:   config.Auth{JWT: oneKey},
+			token:  sign(t, jwt.SigningMethodHS256, []byte(testSecret), "k1", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "hmac token with both methods",
+			auth:     config.Auth{HMAC: hmacAuth, JWT: oneKey},
+			token:    sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims("ops", nil)),
+			status:   http.StatusOK,
+			identity: "ops",
+		},
+		{
+			name:     "jwks token with both methods",
+			auth:     config.Auth{HMAC: hmacAuth, JWT: oneKey},
+			token:    sign(t, jwt.SigningMethodEdDSA, k1.priv, "k1", claims("ci", nil)),
+			status:   http.StatusOK,
+			identity: "ci",
+		},
+		{
+			name:     "kid selects the key",
+			auth:     config.Auth{JWT: twoKeys},
+			token:    sign(t, jwt.SigningMethodEdDSA, k2.priv, "k2", claims("ci", nil)),
+			status:   http.StatusOK,
+			identity: "ci",
+		},
+		{
+			name:   "kid of another key",
+			auth:   config.Auth{JWT: twoKeys},
+			token:  sign(t, jwt.SigningMethodEdDSA, k2.priv, "k1", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown kid",
+			auth:   config.Auth{JWT: twoKeys},
+			token:  sign(t, jwt.SigningMethodEdDSA, k2.priv, "k3", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "no kid with a single key",
+			auth:     config.Auth{JWT: oneKey},
+			token:    sign(t, jwt.SigningMethodEdDSA, k1.priv, "", claims("ci", nil)),
+			status:   http.StatusOK,
+			identity: "ci",
+		},
+		{
+			name:   "no kid with several keys",
+			auth:   config.Auth{JWT: twoKeys},
+			token:  sign(t, jwt.SigningMethodEdDSA, k1.priv, "", claims("ci", nil)),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestAuthenticator(t, tt.auth).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(FromContext(r.Context()).Name))
+			}))
+			r := httptest.NewRequest("POST", "/mcp", nil)
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && w.Body.String() != tt.identity {
+				t.Errorf("identity = %q, want %q", w.Body.String(), tt.identity)
+			}
+			if tt.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestForeignAlgorithmIsLeftToOtherMethods(t *testing.T) {
+	key := newSigningKey(t, "k1")
+	a := newTestAuthenticator(t, config.Auth{
+		Tokens: []config.StaticToken{{Identity: "ops", Token: "ops-token"}},
+		JWT:    &config.JWTAuth{JWKSFile: writeJWKS(t, key)},
+	})
+	r := httptest.NewRequest("POST", "/mcp", nil)
+	r.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, []byte(testSecret), "k1", claims("ci", nil)))
+
+	if _, err := a.Authenticate(r); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("Authenticate = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestAllowsConsumer(t *testing.T) {
+	scoped := &Identity{Name: "ci", Identity: config.Identity{ConsumerIDs: []string{"test-*", "demo"}}}
+	unscoped := &Identity{Name: "ops"}
+	for _, tt := range []struct {
+		id         *Identity
+		consumerID string
+		want       bool
+	}{
+		{scoped, "test-42", true},
+		{scoped, "demo", true},
+		{scoped, "prod-1", false},
+		{scoped, "demo-2", false},
+		{scoped, "", false},
+		{unscoped, "prod-1", true},
+	} {
+		if got := tt.id.AllowsConsumer(tt.consumerID); got != tt.want {
+			t.Errorf("%s.AllowsConsumer(%q) = %t, want %t", tt.id.Name, tt.consumerID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+// staticTokens accepts bearer tokens issued to a single identity each.
+type staticTokens struct {
+	digests  [][sha256.Size]byte
+	subjects []string
+}
+
+func newStaticTokens(tokens []config.StaticToken, identities map[string]config.Identity) (*staticTokens, error) {
+	m := &staticTokens{}
+	for i, t := range tokens {
+		if _, ok := identities[t.Identity]; !ok {
+			return nil, fmt.Errorf("auth: token %d uses unknown identity %q", i+1, t.Identity)
+		}
+		token, err := readSecret(t.Token, t.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: token for %q: %w", t.Identity, err)
+		}
+		if token == "" {
+			return nil, fmt.Errorf("auth: token for %q is empty", t.Identity)
+		}
+		m.digests = append(m.digests, sha256.Sum256([]byte(token)))
+		m.subjects = append(m.subjects, t.Identity)
+	}
+	return m, nil
+}
+
+// Authenticate compares the bearer token with every configured token in
+// constant time, so neither the match nor its position leaks through timing.
+func (m *staticTokens) Authenticate(r *http.Request) (string, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+	digest := sha256.Sum256([]byte(token))
+	subject := ""
+	for i, d := range m.digests {
+		if subtle.ConstantTimeCompare(digest[:], d[:]) == 1 {
+			subject = m.subjects[i]
+		}
+	}
+	if subject == "" {
+		return "", ErrNoCredentials
+	}
+	return subject, nil
+}
+
+// signedTokens accepts JWTs verified with the keys returned by key. Tokens
+// signed with an algorithm outside algs are left to other methods.
+type signedTokens struct {
+	algs   []string
+	parser *jwt.Parser
+	key    jwt.Keyfunc
+}
+
+func newSignedTokens(algs []string, issuer, audience string, key jwt.Keyfunc) *signedTokens {
+	opts := []jwt.ParserOption{jwt.WithValidMethods(algs), jwt.WithExpirationRequired()}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return &signedTokens{algs: algs, parser: jwt.NewParser(opts...), key: key}
+}
+
+func (m *signedTokens) Authenticate(r *http.Request) (string, error) {
+	raw, ok := bearerToken(r)
+	if !ok || strings.Count(raw, ".") != 2 {
+		return "", ErrNoCredentials
+	}
+	unverified, _, err := jwt.NewParser().ParseUnverified(raw, &jwt.RegisteredClaims{})
+	if err != nil || !m.accepts(unverified.Method.Alg()) {
+		return "", ErrNoCredentials
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, err := m.parser.ParseWithClaims(raw, &claims, m.key); err != nil {
+		return "", fmt.Errorf("auth: invalid token: %w", err)
+	}
+	if claims.Subject == "" {
+		return "", errors.New("auth: token has no sub claim")
+	}
+	return claims.Subject, nil
+}
+
+func (m *signedTokens) accepts(alg string) bool {
+	for _, a := range m.algs {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// newHMACTokens accepts JWTs signed with a shared secret.
+func newHMACTokens(cfg config.HMACAuth) (*signedTokens, error) {
+	secret, err := readSecret(cfg.Secret, cfg.SecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("auth: hmac secret: %w", err)
+	}
+	if len(secret) < 32 {
+		return nil, errors.New("auth: hmac secret must be at least 32 bytes")
+	}
+	key := []byte(secret)
+	return newSignedTokens([]string{"HS256", "HS384", "HS512"}, cfg.Issuer, cfg.Audience,
+		func(*jwt.Token) (any, error) { return key, nil }), nil
+}
+
+// newJWKSTokens accepts JWTs signed with one of the public keys of a local
+// JWKS file, selected by the kid header.
+func newJWKSTokens(cfg config.JWTAuth) (*signedTokens, error) {
+	if cfg.JWKSFile == "" {
+		return nil, errors.New("auth: jwt.jwks_file is required")
+	}
+	keys, err := readJWKS(cfg.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	algs := []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+	return newSignedTokens(algs, cfg.Issuer, cfg.Audience, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok && kid == "" && len(keys) == 1 {
+			for _, k := range keys {
+				key, ok = k, true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		return key, nil
+	}), nil
+}
+
+// readSecret returns value, or the trimmed contents of file when value is
+// empty.
+func readSecret(value, file string) (string, error) {
+	if value != "" || file == "" {
+		return value, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
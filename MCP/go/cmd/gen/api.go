@@ -0,0 +1,430 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// operation is everything the templates need to know about one spec
+// operation.
+type operation struct {
+	ID      string // operationId, e.g. connectionsUpdate
+	Name    string // exported client method name, e.g. ConnectionsUpdate
+	Lower   string // file and identifier stem, e.g. connectionsupdate
+	Package string // tools/<Package>, derived from the operation's tag
+	Tool    string // MCP tool name, e.g. patch_vault_connections_unified_api_service_id
+	Method  string
+	Path    string
+	Summary string
+	Public  bool // the operation declares no security requirement
+
+	// Behaviour hints for the tool's annotations
+	Title       string // human-readable tool title
+	ReadOnly    bool   // a GET operation that changes nothing in Vault
+	Destructive bool   // deletes or overwrites data
+	Idempotent  bool   // repeating a call has no further effect
+	OpenWorld   bool   // reaches the downstream connector, not only Vault
+
+	Params []param
+
+	Response string // models type of the 200/201 response
+	Redirect bool   // the operation answers with a 301 instead of a body
+
+	Body        string // Go type of the request body, e.g. models.Consumer
+	Patch       bool   // Body is a generated vault.<Name>Body with Optional fields
+	PatchFields []patchField
+	EmptyBody   bool // the operation takes an empty JSON object as body
+	BodyArgs    []bodyArg
+}
+
+type param struct {
+	Name        string
+	In          string
+	Field       string // Go field name in <Name>Params
+	GoType      string
+	JSONType    string // JSON schema type of the tool argument
+	Description string
+	Required    bool
+	Style       string // queryParam style constant
+	Explode     bool
+}
+
+type patchField struct {
+	Field       string
+	GoType      string
+	Name        string
+	Description string
+}
+
+// bodyArg is a writable body property offered as a tool argument.
+type bodyArg struct {
+	Arg         string // tool argument name
+	Name        string // body property name
+	JSONType    string
+	Required    bool
+	Description string
+}
+
+// HasParams reports whether the operation has a <Name>Params struct.
+func (o *operation) HasParams() bool { return len(o.Params) > 0 }
+
+// HasBody reports whether the handler binds a request body from arguments.
+func (o *operation) HasBody() bool { return o.Body != "" }
+
+// NoContent reports whether the client method returns only an error.
+func (o *operation) NoContent() bool { return o.Response == "" && !o.Redirect }
+
+func (o *operation) Handler() string {
+	return strings.ToUpper(o.Lower[:1]) + o.Lower[1:]
+}
+
+func (o *operation) HTTPMethod() string {
+	return "http.Method" + o.Method[:1] + strings.ToLower(o.Method[1:])
+}
+
+func (o *operation) PathParams() []param   { return o.paramsIn("path") }
+func (o *operation) QueryParams() []param  { return o.paramsIn("query") }
+func (o *operation) HeaderParams() []param { return o.paramsIn("header") }
+
+func (o *operation) paramsIn(in string) []param {
+	var out []param
+	for _, p := range o.Params {
+		if p.In == in && p.Name != appIDHeader {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// HasAppID reports whether the operation takes the application ID, which
+// the client sends as the applicationId security scheme.
+func (o *operation) HasAppID() bool {
+	for _, p := range o.Params {
+		if p.Name == appIDHeader {
+			return true
+		}
+	}
+	return false
+}
+
+const appIDHeader = "x-apideck-app-id"
+
+// stateChangingGETs are the GET operations that change state in Vault all
+// the same, so they are not offered in read-only mode.
+var stateChangingGETs = map[string]bool{
+	"connectionsCallback": true, // completes an OAuth flow and stores its tokens
+	"connectionsRevoke":   true, // revokes the tokens of a connection
+}
+
+// destructiveOperations overwrite or remove data without being a PATCH or
+// DELETE, which always count as destructive.
+var destructiveOperations = map[string]bool{
+	"connectionsImport": true, // replaces the credentials of the connection
+	"connectionsRevoke": true,
+}
+
+// openWorldOperations talk to the downstream connector or its OAuth
+// provider. The other operations stay within Vault's own data.
+var openWorldOperations = map[string]bool{
+	"connectionsAuthorize": true,
+	"connectionsCallback":  true,
+	"connectionsRevoke":    true,
+	"connectionsToken":     true,
+	"connectionsExample":   true,
+	"customFieldsAll":      true,
+}
+
+// toolTitles replace summaries that make poor titles on their own.
+var toolTitles = map[string]string{
+	"connectionsAuthorize": "Get connection authorize link",
+	"connectionsCallback":  "Complete connection authorization",
+}
+
+// annotate derives the behaviour hints of o from its method and the tables
+// above. POST is the only method whose calls are not idempotent.
+func (o *operation) annotate() {
+	o.Title = o.Summary
+	if title, ok := toolTitles[o.ID]; ok {
+		o.Title = title
+	}
+	o.ReadOnly = o.Method == "GET" && !stateChangingGETs[o.ID]
+	o.Destructive = !o.ReadOnly && (o.Method == "DELETE" || o.Method == "PATCH" || destructiveOperations[o.ID])
+	o.Idempotent = o.Method != "POST"
+	o.OpenWorld = openWorldOperations[o.ID]
+}
+
+// operations collects the spec's operations in spec order.
+func (s *Spec) operations() ([]*operation, error) {
+	var ops []*operation
+	for _, path := range s.Paths.Keys {
+		item := s.Paths.Values[path]
+		for _, method := range item.Keys {
+			op, err := s.operation(path, method, item.Values[method])
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
+func (s *Spec) operation(path, method string, op *Operation) (*operation, error) {
+	if op.OperationID == "" {
+		return nil, fmt.Errorf("missing operationId")
+	}
+	if len(op.Tags) == 0 {
+		return nil, fmt.Errorf("%s: missing tag", op.OperationID)
+	}
+	o := &operation{
+		ID:      op.OperationID,
+		Name:    exported(op.OperationID),
+		Lower:   strings.ToLower(op.OperationID),
+		Package: strings.ReplaceAll(strings.ToLower(op.Tags[0]), " ", "_"),
+		Tool:    toolName(method, path),
+		Method:  strings.ToUpper(method),
+		Path:    path,
+		Summary: op.Summary,
+		Public:  op.Security != nil && len(*op.Security) == 0,
+	}
+	o.annotate()
+
+	for _, ref := range op.Parameters {
+		p := s.parameter(ref)
+		if p == nil {
+			return nil, fmt.Errorf("%s: unresolved parameter %s", o.ID, ref.Ref)
+		}
+		style := p.Style
+		if style == "" {
+			style = "form"
+		}
+		explode := style == "form"
+		if p.Explode != nil {
+			explode = *p.Explode
+		}
+		o.Params = append(o.Params, param{
+			Name:        p.Name,
+			In:          p.In,
+			Field:       fieldName(strings.TrimPrefix(p.Name, "x-apideck-")),
+			GoType:      s.paramGoType(p.Schema),
+			JSONType:    s.jsonType(p.Schema),
+			Description: p.Description,
+			Required:    p.Required,
+			Style:       "style" + exported(style),
+			Explode:     explode,
+		})
+	}
+
+	for _, code := range []string{"200", "201"} {
+		if r, ok := op.Responses.Values[code]; ok {
+			if name := s.responseType(r); name != "" {
+				o.Response = name
+				break
+			}
+		}
+	}
+	if o.Response == "" {
+		_, o.Redirect = op.Responses.Values["301"]
+	}
+
+	if op.RequestBody != nil {
+		if err := s.requestBody(o, op.RequestBody); err != nil {
+			return nil, fmt.Errorf("%s: %w", o.ID, err)
+		}
+	}
+	return o, nil
+}
+
+// responseType returns the models type of a JSON response.
+func (s *Spec) responseType(r *Response) string {
+	if r.Ref != "" {
+		r = s.Components.Responses[refName(r.Ref)]
+	}
+	if r == nil {
+		return ""
+	}
+	mt, ok := r.Content["application/json"]
+	if !ok || mt.Schema == nil || mt.Schema.Ref == "" {
+		return ""
+	}
+	return refName(mt.Schema.Ref)
+}
+
+func (s *Spec) requestBody(o *operation, rb *RequestBody) error {
+	mt, ok := rb.Content["application/json"]
+	if !ok || mt.Schema == nil {
+		return fmt.Errorf("request body is not application/json")
+	}
+	if mt.Schema.Ref == "" {
+		if isObject(mt.Schema) && len(mt.Schema.Properties.Keys) == 0 {
+			o.EmptyBody = true
+			return nil
+		}
+		return fmt.Errorf("inline request body schemas are not supported")
+	}
+
+	o.Body = "models." + refName(mt.Schema.Ref)
+	if o.Method == "PATCH" {
+		o.Body = o.Name + "Body"
+		o.Patch = true
+	}
+
+	sc := s.schema(mt.Schema)
+	required := make(map[string]bool)
+	for _, name := range sc.Required {
+		required[name] = true
+	}
+	taken := make(map[string]bool)
+	for _, p := range o.Params {
+		taken[p.Name] = true
+	}
+	for _, name := range sc.Properties.Keys {
+		prop := sc.Properties.Values[name]
+		resolved := s.schema(prop)
+		if prop.ReadOnly || resolved.ReadOnly {
+			continue
+		}
+		description := prop.Description
+		if description == "" {
+			description = resolved.Description
+		}
+		arg := name
+		if taken[name] {
+			arg = "body_" + name
+		}
+		o.BodyArgs = append(o.BodyArgs, bodyArg{
+			Arg:         arg,
+			Name:        name,
+			JSONType:    s.jsonType(prop),
+			Required:    required[name],
+			Description: description,
+		})
+		if o.Patch {
+			o.PatchFields = append(o.PatchFields, patchField{
+				Field:       fieldName(name),
+				GoType:      s.patchGoType(prop),
+				Name:        name,
+				Description: firstLine(description),
+			})
+		}
+	}
+	return nil
+}
+
+// paramGoType is the type of a parameter field. Optional scalars are
+// pointers so that false and 0 can be told apart from "not supplied".
+func (s *Spec) paramGoType(sc *Schema) string {
+	if sc == nil {
+		return "string"
+	}
+	if sc.Ref != "" {
+		return "*models." + refName(sc.Ref)
+	}
+	switch sc.Type {
+	case "array":
+		return "[]string"
+	case "boolean":
+		return "*bool"
+	case "integer":
+		return "*int"
+	}
+	return "string"
+}
+
+// patchGoType is the type wrapped in Optional for a PATCH body field.
+func (s *Spec) patchGoType(sc *Schema) string {
+	r := s.schema(sc)
+	switch r.Type {
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		return "int"
+	case "array":
+		items := r.Items
+		if items == nil {
+			return "[]interface{}"
+		}
+		if items.Ref != "" {
+			if isObject(s.schema(items)) {
+				return "[]models." + refName(items.Ref)
+			}
+			return "[]" + s.patchGoType(items)
+		}
+		if items.Type == "string" {
+			return "[]string"
+		}
+		return "[]map[string]interface{}"
+	}
+	return "map[string]interface{}"
+}
+
+// jsonType is the JSON schema type of a tool argument.
+func (s *Spec) jsonType(sc *Schema) string {
+	r := s.schema(sc)
+	switch {
+	case r == nil:
+		return "string"
+	case r.Type != "":
+		return r.Type
+	case isObject(r):
+		return "object"
+	}
+	return "string"
+}
+
+// toolName derives the MCP tool name from the method and path, e.g.
+// GET /vault/consumers/{consumer_id} becomes get_vault_consumers_consumer_id.
+func toolName(method, path string) string {
+	name := strings.Trim(path, "/")
+	name = strings.NewReplacer("/", "_", "{", "", "}", "").Replace(name)
+	return strings.ToLower(method) + "_" + name
+}
+
+// fieldName converts a snake_case or kebab-case name to a Go field name,
+// keeping common initialisms upper case: consumer_id becomes ConsumerID.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		switch part {
+		case "id", "api", "uri", "url":
+			b.WriteString(strings.ToUpper(part))
+		default:
+			b.WriteString(exported(part))
+		}
+	}
+	return b.String()
+}
+
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	return strings.TrimSpace(s)
+}
+
+// packages returns the tools packages in a stable order.
+func packages(ops []*operation) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, o := range ops {
+		if !seen[o.Package] {
+			seen[o.Package] = true
+			out = append(out, o.Package)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,180 @@
+// Command gen regenerates the Vault client operations, the MCP tools, the
+// models and the tool registry from openapi.yaml.
+//
+// It is run from the module root through go generate:
+//
+//	go generate ./...
+//
+// With -check it writes nothing and exits with status 1 when any generated
+// file differs from what the spec produces, so CI can catch hand edits and
+// specs that were updated without regenerating.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "../../openapi.yaml", "path to the OpenAPI spec")
+	outDir := flag.String("out", ".", "module root to write generated files to")
+	check := flag.Bool("check", false, "report files that differ from the spec instead of writing them")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	stale, err := staleFiles(*outDir, files)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *check {
+		drift, err := diff(*outDir, files)
+		if err != nil {
+			log.Fatal(err)
+		}
+		drift = append(drift, stale...)
+		if len(drift) > 0 {
+			sort.Strings(drift)
+			for _, name := range drift {
+				fmt.Fprintf(os.Stderr, "gen: %s is out of date\n", name)
+			}
+			log.Fatal("generated code does not match the spec; run go generate ./...")
+		}
+		return
+	}
+
+	for _, name := range sortedKeys(files) {
+		path := filepath.Join(*outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, name := range stale {
+		if err := os.Remove(filepath.Join(*outDir, name)); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate renders every generated file, keyed by its slash-separated path
+// relative to the module root.
+func generate(spec *Spec) (map[string][]byte, error) {
+	ops, err := spec.operations()
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	render := func(name, tmpl string, data any) error {
+		var buf bytes.Buffer
+		buf.WriteString(header)
+		if err := templates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: formatting generated code: %w", name, err)
+		}
+		files[name] = src
+		return nil
+	}
+
+	if err := render("models/models.go", "models", spec.models()); err != nil {
+		return nil, err
+	}
+	if err := render("vault/operations.go", "operations", ops); err != nil {
+		return nil, err
+	}
+	for _, o := range ops {
+		name := "tools/" + o.Package + "/" + o.Lower + ".go"
+		if _, dup := files[name]; dup {
+			return nil, fmt.Errorf("%s: generated twice, operationIds must differ in more than case", name)
+		}
+		if err := render(name, "tool", o); err != nil {
+			return nil, err
+		}
+	}
+
+	registered := append([]*operation(nil), ops...)
+	sort.SliceStable(registered, func(i, j int) bool {
+		return registered[i].Package < registered[j].Package
+	})
+	registry := struct {
+		Packages   []string
+		Operations []*operation
+	}{packages(ops), registered}
+	if err := render("registry.go", "registry", registry); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// diff returns the generated files whose content on disk differs.
+func diff(root string, files map[string][]byte) ([]string, error) {
+	var drift []string
+	for _, name := range sortedKeys(files) {
+		current, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if !bytes.Equal(current, files[name]) {
+			drift = append(drift, name)
+		}
+	}
+	return drift, nil
+}
+
+// staleFiles returns previously generated tool files that the spec no longer
+// produces, e.g. after an operation was removed or renamed.
+func staleFiles(root string, files map[string][]byte) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, "tools", "*", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, path := range matches {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.ToSlash(rel)
+		if _, ok := files[name]; ok {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(string(src), header) {
+			stale = append(stale, name)
+		}
+	}
+	return stale, nil
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,79 @@
+package main
+
+import "go/token"
+
+// model is a component schema emitted as a struct in models/models.go.
+type model struct {
+	Name   string
+	Fields []modelField
+}
+
+type modelField struct {
+	Field       string
+	GoType      string
+	Tag         string
+	Description string
+}
+
+// models returns a struct for every object schema, in spec order. Scalar
+// and enum schemas are used through their underlying type instead.
+func (s *Spec) models() []model {
+	var out []model
+	for _, name := range s.Components.Schemas.Keys {
+		sc := s.Components.Schemas.Values[name]
+		if sc.Ref != "" || !isObject(sc) {
+			continue
+		}
+		required := make(map[string]bool)
+		for _, r := range sc.Required {
+			required[r] = true
+		}
+		m := model{Name: name}
+		for _, prop := range sc.Properties.Keys {
+			field := exported(prop)
+			if token.IsKeyword(prop) || prop == "error" {
+				field = exported(prop) + "Field"
+			}
+			tag := prop
+			if !required[prop] {
+				tag += ",omitempty"
+			}
+			m.Fields = append(m.Fields, modelField{
+				Field:       field,
+				GoType:      s.modelGoType(sc.Properties.Values[prop]),
+				Tag:         tag,
+				Description: firstLine(sc.Properties.Values[prop].Description),
+			})
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func (s *Spec) modelGoType(sc *Schema) string {
+	if sc.Ref != "" {
+		if r := s.schema(sc); isObject(r) {
+			return refName(sc.Ref)
+		}
+		return s.modelGoType(s.schema(sc))
+	}
+	switch sc.Type {
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "array":
+		if sc.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + s.modelGoType(sc.Items)
+	case "object":
+		return "map[string]interface{}"
+	}
+	// Untyped schemas and anyOf unions can hold any JSON value.
+	return "interface{}"
+}
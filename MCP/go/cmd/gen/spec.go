@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The types below model the subset of OpenAPI 3 that openapi.yaml uses.
+// Maps whose order is reflected in the generated code are decoded into
+// ordered so that output follows the spec rather than Go's map order.
+
+type Spec struct {
+	Paths      ordered[ordered[*Operation]] `yaml:"paths"`
+	Components Components                   `yaml:"components"`
+}
+
+type Components struct {
+	Parameters map[string]*Parameter `yaml:"parameters"`
+	Responses  map[string]*Response  `yaml:"responses"`
+	Schemas    ordered[*Schema]      `yaml:"schemas"`
+}
+
+type Operation struct {
+	OperationID string                 `yaml:"operationId"`
+	Summary     string                 `yaml:"summary"`
+	Tags        []string               `yaml:"tags"`
+	Parameters  []*Parameter           `yaml:"parameters"`
+	RequestBody *RequestBody           `yaml:"requestBody"`
+	Responses   ordered[*Response]     `yaml:"responses"`
+	Security    *[]map[string][]string `yaml:"security"`
+}
+
+type Parameter struct {
+	Ref         string  `yaml:"$ref"`
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Style       string  `yaml:"style"`
+	Explode     *bool   `yaml:"explode"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+type Schema struct {
+	Ref         string           `yaml:"$ref"`
+	Type        string           `yaml:"type"`
+	Description string           `yaml:"description"`
+	ReadOnly    bool             `yaml:"readOnly"`
+	Required    []string         `yaml:"required"`
+	Properties  ordered[*Schema] `yaml:"properties"`
+	Items       *Schema          `yaml:"items"`
+	AnyOf       []*Schema        `yaml:"anyOf"`
+}
+
+// ordered is a YAML mapping that remembers the order of its keys.
+type ordered[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+func (m *ordered[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	m.Values = make(map[string]T, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		m.Keys = append(m.Keys, key)
+		m.Values[key] = value
+	}
+	return nil
+}
+
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// parameter resolves a reference to a shared parameter.
+func (s *Spec) parameter(p *Parameter) *Parameter {
+	if p.Ref != "" {
+		return s.Components.Parameters[refName(p.Ref)]
+	}
+	return p
+}
+
+// schema follows references until it reaches a schema definition.
+func (s *Spec) schema(sc *Schema) *Schema {
+	for sc != nil && sc.Ref != "" {
+		sc = s.Components.Schemas.Values[refName(sc.Ref)]
+	}
+	return sc
+}
+
+// isObject reports whether sc describes a JSON object, which the generator
+// turns into a struct.
+func isObject(sc *Schema) bool {
+	return sc.Type == "object" || len(sc.Properties.Keys) > 0
+}
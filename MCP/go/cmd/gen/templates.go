@@ -0,0 +1,224 @@
+package main
+
+import (
+	"strconv"
+	"text/template"
+)
+
+const header = "// Code generated by cmd/gen from openapi.yaml. DO NOT EDIT.\n\n"
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote":     strconv.Quote,
+	"firstLine": firstLine,
+	"withType": func(jsonType string) string {
+		switch jsonType {
+		case "boolean":
+			return "WithBoolean"
+		case "number", "integer":
+			return "WithNumber"
+		case "array":
+			return "WithArray"
+		case "object":
+			return "WithObject"
+		}
+		return "WithString"
+	},
+}).Parse(`
+{{define "models"}}package models
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type Tool struct {
+	Definition mcp.Tool
+	Handler    func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Category is the API area of the tool, e.g. "consumers".
+	Category string
+}
+{{range .}}
+// {{.Name}} represents the {{.Name}} schema from the OpenAPI specification
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Field}} {{.GoType}} ` + "`" + `json:"{{.Tag}}"` + "`" + `{{with .Description}} // {{.}}{{end}}
+{{- end}}
+}
+{{end}}{{end}}
+
+{{define "operations"}}package vault
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vault-api/mcp-server/models"
+)
+{{range .}}{{if .HasParams}}
+// {{.Name}}Params holds the path, query and header parameters of {{.Name}}.
+type {{.Name}}Params struct {
+{{- range .Params}}
+	{{.Field}} {{.GoType}} ` + "`" + `json:"{{.Name}}"` + "`" + `{{with firstLine .Description}} // {{.}}{{end}}
+{{- end}}
+}
+{{end}}{{if .Patch}}
+// {{.Name}}Body is the PATCH request body of {{.Name}}.
+// Only the fields that are set are sent, so explicit false, 0, "" and
+// null values reach Vault unchanged.
+type {{.Name}}Body struct {
+{{- range .PatchFields}}
+	{{.Field}} Optional[{{.GoType}}] ` + "`" + `json:"{{.Name}},omitzero"` + "`" + `{{with .Description}} // {{.}}{{end}}
+{{- end}}
+}
+{{end}}
+// {{.Name}} calls {{.ID}} ({{.Summary}}).
+//
+//	{{.Method}} {{.Path}}
+func (c *Client) {{.Name}}(ctx context.Context{{if .HasParams}}, params {{.Name}}Params{{end}}{{if .HasBody}}, body {{.Body}}{{end}}) {{if .Response}}(*models.{{.Response}}, error){{else if .Redirect}}(*Redirect, error){{else}}error{{end}} {
+{{- if .Response}}
+	var out models.{{.Response}}
+{{- else if .Redirect}}
+	var out Redirect
+{{- end}}
+	{{if .NoContent}}return{{else}}err :={{end}} c.do(ctx, request{
+		method: {{.HTTPMethod}},
+		path: {{quote .Path}},
+{{- with .PathParams}}
+		pathParams: map[string]string{
+{{- range .}}
+			{{quote .Name}}: params.{{.Field}},
+{{- end}}
+		},
+{{- end}}
+{{- with .QueryParams}}
+		query: []queryParam{
+{{- range .}}
+			{name: {{quote .Name}}, style: {{.Style}}{{if .Explode}}, explode: true{{end}}, value: params.{{.Field}}},
+{{- end}}
+		},
+{{- end}}
+{{- if .Public}}
+		public: true,
+{{- end}}
+{{- if .HasAppID}}
+		appID: params.AppID,
+{{- end}}
+{{- with .HeaderParams}}
+		header: map[string]string{
+{{- range .}}
+			{{quote .Name}}: params.{{.Field}},
+{{- end}}
+		},
+{{- end}}
+{{- if .HasBody}}
+		body: body,
+{{- else if .EmptyBody}}
+		body: struct{}{},
+{{- end}}
+{{- if .NoContent}}
+	}, nil)
+}
+{{else}}
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+{{end}}{{end}}{{end}}
+
+{{define "tool"}}package tools
+
+import (
+	"context"
+{{- if or .HasParams .HasBody}}
+	"fmt"
+{{- end}}
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+	"github.com/vault-api/mcp-server/tools/common"
+{{- if or .HasParams .Patch}}
+	"github.com/vault-api/mcp-server/vault"
+{{- end}}
+)
+{{if .HasBody}}
+// {{.Lower}}BodyArguments maps the tool arguments that make up the request
+// body to body field names. Read-only properties are not offered as arguments.
+var {{.Lower}}BodyArguments = map[string]string{
+{{- range .BodyArgs}}
+	{{quote .Arg}}: {{quote .Name}},
+{{- end}}
+}
+{{end}}
+func {{.Handler}}Handler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+{{- if or .HasParams .HasBody}}
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+{{- end}}
+{{- if .HasParams}}
+		var params vault.{{.Name}}Params
+		if err := common.BindArguments(args, &params); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to parameters: %v", err)), nil
+		}
+{{- end}}
+{{- if .HasBody}}
+		// Create properly typed request body using the generated schema
+		var requestBody {{if .Patch}}vault.{{end}}{{.Body}}
+		if err := common.BindBody(args, {{.Lower}}BodyArguments, &requestBody); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
+		}
+{{- end}}
+		return common.{{if .NoContent}}NoContentResult{{else}}Result{{end}}(common.Client(ctx, cfg).{{.Name}}(ctx{{if .HasParams}}, params{{end}}{{if .HasBody}}, requestBody{{end}}))
+	}
+}
+
+func Create{{.Handler}}Tool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool({{quote .Tool}},
+		mcp.WithDescription({{quote .Summary}}),
+		mcp.WithTitleAnnotation({{quote .Title}}),
+		mcp.WithReadOnlyHintAnnotation({{.ReadOnly}}),
+		mcp.WithDestructiveHintAnnotation({{.Destructive}}),
+		mcp.WithIdempotentHintAnnotation({{.Idempotent}}),
+		mcp.WithOpenWorldHintAnnotation({{.OpenWorld}}),
+{{- range .Params}}
+		mcp.{{withType .JSONType}}({{quote .Name}},{{if .Required}} mcp.Required(),{{end}} mcp.Description({{quote .Description}})),
+{{- end}}
+{{- range .BodyArgs}}
+		mcp.{{withType .JSONType}}({{quote .Arg}},{{if .Required}} mcp.Required(),{{end}} mcp.Description({{if .Description}}{{printf "Input parameter: %s" .Description | quote}}{{else}}""{{end}})),
+{{- end}}
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    {{.Handler}}Handler(cfg),
+		Category:   {{quote .Package}},
+	}
+}
+{{end}}
+
+{{define "registry"}}package main
+
+import (
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+{{- range .Packages}}
+	tools_{{.}} "github.com/vault-api/mcp-server/tools/{{.}}"
+{{- end}}
+)
+
+func GetAll(cfg *config.APIConfig) []models.Tool {
+	return []models.Tool{
+{{- range .Operations}}
+		tools_{{.Package}}.Create{{.Handler}}Tool(cfg),
+{{- end}}
+	}
+}
+{{end}}
+`))
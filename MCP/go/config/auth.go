@@ -0,0 +1,73 @@
+package config
+
+// Auth configures the authentication of inbound HTTP clients. It is read
+// from the auth section of the configuration file:
+//
+//	auth:
+//	  tokens:
+//	    - identity: ci
+//	      token_file: /run/secrets/mcp_ci_token
+//	  hmac:
+//	    secret_file: /run/secrets/mcp_hmac_secret
+//	  jwt:
+//	    jwks_file: /etc/mcp/jwks.json
+//	    issuer: https://idp.example.com
+//	    audience: vault-mcp
+//	  identities:
+//	    ci:
+//	      profile: sandbox
+//	      app_id: sandbox-app
+//	      consumer_ids: ["test-*"]
+//
+// Authentication is enabled as soon as any method is configured. Tokens are
+// mapped to an identity by name; HMAC-signed and JWT tokens by their sub
+// claim.
+type Auth struct {
+	Tokens     []StaticToken       `yaml:"tokens"`
+	HMAC       *HMACAuth           `yaml:"hmac"`
+	JWT        *JWTAuth            `yaml:"jwt"`
+	Identities map[string]Identity `yaml:"identities"`
+}
+
+// StaticToken is a bearer token issued to a single identity.
+type StaticToken struct {
+	Identity  string `yaml:"identity"`
+	Token     string `yaml:"token"`
+	TokenFile string `yaml:"token_file"`
+}
+
+// HMACAuth accepts tokens signed with a shared secret (JWTs using HS256,
+// HS384 or HS512).
+type HMACAuth struct {
+	Secret     string `yaml:"secret"`
+	SecretFile string `yaml:"secret_file"`
+	Issuer     string `yaml:"issuer"`
+	Audience   string `yaml:"audience"`
+}
+
+// JWTAuth accepts JWTs signed with one of the keys of a local JWKS file.
+type JWTAuth struct {
+	JWKSFile string `yaml:"jwks_file"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+}
+
+// Identity is what an authenticated client may do.
+type Identity struct {
+	// Profile is the only profile the identity may use; the server's
+	// profile when empty. Credentials always come from the profile, never
+	// from request headers.
+	Profile string `yaml:"profile"`
+
+	// AppID, if set, is the only x-apideck-app-id the identity may use.
+	AppID string `yaml:"app_id"`
+
+	// ConsumerIDs are patterns such as "test-*" (path.Match syntax) for the
+	// consumer IDs the identity may use. Any consumer is allowed when empty.
+	ConsumerIDs []string `yaml:"consumer_ids"`
+}
+
+// Enabled reports whether any authentication method is configured.
+func (a Auth) Enabled() bool {
+	return len(a.Tokens) > 0 || a.HMAC != nil || a.JWT != nil
+}
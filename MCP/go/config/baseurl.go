@@ -0,0 +1,115 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrBaseURLNotAllowed is returned for a base URL that BaseURLPolicy rejects.
+var ErrBaseURLNotAllowed = errors.New("base URL not allowed")
+
+// BaseURLPolicy restricts the base URLs that callers may supply through the
+// API_BASE_URL header in HTTP mode. Base URLs from the environment or a
+// profile are trusted and not checked.
+type BaseURLPolicy struct {
+	// Allowed lists base URLs such as "https://unify.apideck.com", which
+	// match that origin and any path below it, or host patterns such as
+	// "*.apideck.com" (path.Match syntax). "*" allows any host. When
+	// empty, only the origin of Configured is allowed.
+	Allowed []string
+	// Configured is the base URL from the environment or profile, if any.
+	Configured string
+
+	AllowHTTP    bool // Accept http:// base URLs, not only https://
+	AllowPrivate bool // Accept hosts on loopback, private and link-local addresses
+}
+
+// Check reports whether rawURL may be used as a base URL. Unless private
+// addresses are allowed, the host is resolved and rejected if any of its
+// addresses is not public. The returned error wraps ErrBaseURLNotAllowed for
+// rejected URLs.
+func (p BaseURLPolicy) Check(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute URL", ErrBaseURLNotAllowed, rawURL)
+	}
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && p.AllowHTTP:
+	default:
+		return fmt.Errorf("%w: %q must use https", ErrBaseURLNotAllowed, rawURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: %q must not contain credentials", ErrBaseURLNotAllowed, rawURL)
+	}
+	allowed := p.Allowed
+	if len(allowed) == 0 {
+		origin, err := url.Parse(p.Configured)
+		if err != nil || origin.Host == "" {
+			return fmt.Errorf("%w: %q: no ALLOWED_BASE_URLS are configured", ErrBaseURLNotAllowed, rawURL)
+		}
+		allowed = []string{origin.Scheme + "://" + origin.Host}
+	}
+	if !allows(allowed, u) {
+		return fmt.Errorf("%w: %q is not on the allowlist", ErrBaseURLNotAllowed, rawURL)
+	}
+	if p.AllowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: resolving %s: %v", ErrBaseURLNotAllowed, u.Hostname(), err)
+	}
+	for _, addr := range addrs {
+		if !PublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to non-public address %s", ErrBaseURLNotAllowed, u.Hostname(), addr.Unmap())
+		}
+	}
+	return nil
+}
+
+// allows reports whether u matches one of the Allowed entries.
+func allows(entries []string, u *url.URL) bool {
+	for _, entry := range entries {
+		if !strings.Contains(entry, "://") {
+			if ok, _ := path.Match(strings.ToLower(entry), strings.ToLower(u.Hostname())); ok {
+				return true
+			}
+			continue
+		}
+		allowed, err := url.Parse(entry)
+		if err != nil {
+			continue
+		}
+		if !strings.EqualFold(allowed.Scheme, u.Scheme) || !strings.EqualFold(allowed.Host, u.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(allowed.Path, "/")
+		if prefix == "" || u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicAddr reports whether addr is a public unicast address, i.e. not
+// loopback, private, link-local (including cloud metadata endpoints such as
+// 169.254.169.254), carrier-grade NAT, multicast or unspecified.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestBaseURLPolicyCheck(t *testing.T) {
+	anyHost := []string{"*"}
+	tests := []struct {
+		name    string
+		policy  BaseURLPolicy
+		url     string
+		allowed bool
+	}{
+		{name: "plain http rejected", policy: BaseURLPolicy{Allowed: anyHost}, url: "http://93.184.216.34"},
+		{name: "plain http opted in", policy: BaseURLPolicy{Allowed: anyHost, AllowHTTP: true}, url: "http://93.184.216.34", allowed: true},
+		{name: "public https", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://93.184.216.34", allowed: true},
+		{name: "loopback", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://127.0.0.1:8443"},
+		{name: "private", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://10.1.2.3"},
+		{name: "metadata endpoint", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://169.254.169.254/latest"},
+		{name: "ipv6 loopback", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://[::1]"},
+		{name: "ipv4-mapped loopback", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://[::ffff:127.0.0.1]"},
+		{name: "localhost name", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://localhost"},
+		{name: "private opted in", policy: BaseURLPolicy{Allowed: anyHost, AllowPrivate: true}, url: "https://10.1.2.3", allowed: true},
+		{name: "credentials in URL", policy: BaseURLPolicy{Allowed: anyHost}, url: "https://user:pw@93.184.216.34"},
+		{name: "relative URL", policy: BaseURLPolicy{Allowed: anyHost}, url: "/vault"},
+		{name: "no allowlist or configured base URL", url: "https://93.184.216.34"},
+		{
+			name:    "configured origin",
+			policy:  BaseURLPolicy{Configured: "https://unify.example.com/vault", AllowPrivate: true},
+			url:     "https://unify.example.com/v2",
+			allowed: true,
+		},
+		{
+			name:   "configured origin only",
+			policy: BaseURLPolicy{Configured: "https://unify.example.com", AllowPrivate: true},
+			url:    "https://other.example.com",
+		},
+		{
+			name:   "allowlist replaces configured origin",
+			policy: BaseURLPolicy{Allowed: []string{"*.example.org"}, Configured: "https://unify.example.com", AllowPrivate: true},
+			url:    "https://unify.example.com",
+		},
+		{
+			name:    "host pattern",
+			policy:  BaseURLPolicy{Allowed: []string{"*.example.com"}, AllowPrivate: true},
+			url:     "https://unify.Example.com/v1",
+			allowed: true,
+		},
+		{
+			name:   "host pattern mismatch",
+			policy: BaseURLPolicy{Allowed: []string{"*.example.com"}, AllowPrivate: true},
+			url:    "https://example.com.evil.test",
+		},
+		{
+			name:    "URL prefix",
+			policy:  BaseURLPolicy{Allowed: []string{"https://unify.example.com/v1"}, AllowPrivate: true},
+			url:     "https://unify.example.com/v1/",
+			allowed: true,
+		},
+		{
+			name:   "URL prefix stops at segment boundary",
+			policy: BaseURLPolicy{Allowed: []string{"https://unify.example.com/v1"}, AllowPrivate: true},
+			url:    "https://unify.example.com/v10",
+		},
+		{
+			name:   "URL prefix requires same port",
+			policy: BaseURLPolicy{Allowed: []string{"https://unify.example.com"}, AllowPrivate: true},
+			url:    "https://unify.example.com:8443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(context.Background(), tt.url)
+			if tt.allowed && err != nil {
+				t.Fatalf("Check(%q) = %v, want nil", tt.url, err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrBaseURLNotAllowed) {
+				t.Fatalf("Check(%q) = %v, want ErrBaseURLNotAllowed", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"0.0.0.0":         false,
+		"100.64.0.1":      false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"224.0.0.1":       false,
+	} {
+		if got := PublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("PublicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type APIConfig struct {
@@ -11,6 +15,104 @@ type APIConfig struct {
 	APIKey      string // For API key authentication
 	BasicAuth   string // For basic authentication
 	Port        string // For server port configuration
+	MetricsPort string // Port of a separate /metrics listener, if any
+	AuditLog    string // Audit log destination: "stdout", "stderr" or a file path
+
+	// DownstreamAuthorization is sent as the x-apideck-downstream-authorization
+	// header, which makes Vault skip its own token injection.
+	DownstreamAuthorization string
+
+	AppID      string // Default x-apideck-app-id for tools that take one
+	ConsumerID string // Default x-apideck-consumer-id for tools that take one
+
+	Timeout      time.Duration            // Deadline for every tool call, 0 for none
+	ToolTimeouts map[string]time.Duration // Per-tool deadlines, keyed by tool name
+
+	MaxRetries         int           // Retries after the first attempt, 0 disables retries
+	RetryBaseDelay     time.Duration // Backoff before the first retry, doubled for each further one
+	RetryMaxDelay      time.Duration // Upper bound for a backoff or a Retry-After wait
+	RetryNonIdempotent bool          // Also retry POST, PATCH and possibly executed DELETE operations
+
+	// ShutdownTimeout is how long a shutdown waits for running tool calls
+	// before cancelling them.
+	ShutdownTimeout time.Duration
+
+	// ReadyCacheTTL is how long the result of a readiness check is reused.
+	ReadyCacheTTL time.Duration
+
+	// BaseURLPolicy restricts base URLs supplied by callers in HTTP mode.
+	BaseURLPolicy BaseURLPolicy
+	// CallerBaseURL is set when BaseURL was supplied by the caller, so that
+	// connections to it are held to BaseURLPolicy as well.
+	CallerBaseURL bool
+
+	// EnabledTools limits the tools offered to those matching an entry; all
+	// tools are offered when it is empty. DisabledTools takes tools matching
+	// an entry away again. Entries are tool names, patterns such as
+	// "get_vault_*" (path.Match syntax) or categories such as "consumers".
+	EnabledTools  []string
+	DisabledTools []string
+
+	// ReadOnly limits the tools offered to those that change nothing in Vault.
+	ReadOnly bool
+
+	// ConfirmDestructive makes deletes and revokes wait for the user's
+	// confirmation.
+	ConfirmDestructive bool
+
+	// DryRun makes tools that change data only report the request they
+	// would send, as if every call passed dry_run.
+	DryRun bool
+
+	File    *File  // Configuration file loaded from CONFIG_FILE, nil if none
+	Profile string // Name of the profile in File this configuration came from
+
+	env *environment
+}
+
+// ToolTimeout returns the deadline for calls to the named tool, or 0 when
+// calls to it are not bounded.
+func (c *APIConfig) ToolTimeout(name string) time.Duration {
+	if timeout, ok := c.ToolTimeouts[name]; ok {
+		return timeout
+	}
+	return c.Timeout
+}
+
+// ToolEnabled reports whether a tool with the given name, category and
+// read-only flag should be offered.
+func (c *APIConfig) ToolEnabled(name, category string, readOnly bool) bool {
+	if c.ReadOnly && !readOnly {
+		return false
+	}
+	if len(c.EnabledTools) > 0 && !matchTool(c.EnabledTools, name, category) {
+		return false
+	}
+	return !matchTool(c.DisabledTools, name, category)
+}
+
+// matchTool reports whether a tool matches one of entries, by name, by
+// pattern or by category.
+func matchTool(entries []string, name, category string) bool {
+	for _, entry := range entries {
+		if entry == category {
+			return true
+		}
+		if ok, _ := path.Match(entry, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkToolPatterns rejects malformed patterns in a tool list.
+func checkToolPatterns(setting string, entries []string) error {
+	for _, entry := range entries {
+		if _, err := path.Match(entry, ""); err != nil {
+			return fmt.Errorf("invalid %s entry %q: %w", setting, entry, err)
+		}
+	}
+	return nil
 }
 
 func LoadAPIConfig() (*APIConfig, error) {
@@ -20,29 +122,205 @@ func LoadAPIConfig() (*APIConfig, error) {
 		port = os.Getenv("port")
 	}
 	
-	baseURL := os.Getenv("API_BASE_URL")
-	
 	// Check transport environment variable (both uppercase and lowercase)
 	transport := os.Getenv("TRANSPORT")
 	if transport == "" {
 		transport = os.Getenv("transport")
 	}
+
+	env, err := loadEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	var file *File
+	if path := env.get("CONFIG_FILE"); path != "" {
+		if file, err = LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := load(env, file, env.get("PROFILE"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Port = port
+	cfg.MetricsPort = env.get("METRICS_PORT")
+	cfg.AuditLog = env.get("AUDIT_LOG")
 	
-	// For STDIO mode (transport is not "http"/"HTTP"/"https"/"HTTPS"), API_BASE_URL is required from environment
-	if transport != "http" && transport != "HTTP" && transport != "https" && transport != "HTTPS" && baseURL == "" {
+	// For STDIO mode (transport is not "http"/"HTTP"/"https"/"HTTPS"/"sse"/"SSE"), API_BASE_URL is required from environment
+	isHTTP := transport == "http" || transport == "HTTP" || transport == "https" || transport == "HTTPS" || transport == "sse" || transport == "SSE"
+	if !isHTTP && cfg.BaseURL == "" {
 		return nil, fmt.Errorf("API_BASE_URL environment variable not set")
 	}
 	
-	// For HTTP/HTTPS mode (transport is "http"/"HTTP"/"https"/"HTTPS"), API_BASE_URL comes from headers
+	// For HTTP/HTTPS/SSE mode, API_BASE_URL comes from headers
 	// so we don't require it from environment variables
 
-	return &APIConfig{
-		BaseURL:     baseURL,
-		BearerToken: os.Getenv("BEARER_TOKEN"),
-		APIKey:      os.Getenv("API_KEY"),
-		BasicAuth:   os.Getenv("BASIC_AUTH"),
-		Port:        port,
-	}, nil
+	return cfg, nil
+}
+
+// WithProfile returns the configuration for another profile of the same
+// configuration file, with environment variables applied on top as usual.
+// An empty name selects c's own profile. Port, MetricsPort and AuditLog are kept from c.
+func (c *APIConfig) WithProfile(name string) (*APIConfig, error) {
+	if c.File == nil {
+		return nil, fmt.Errorf("unknown profile %q: no CONFIG_FILE is loaded", name)
+	}
+	if name == "" {
+		name = c.Profile
+	}
+	cfg, err := load(c.env, c.File, name)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Port = c.Port
+	cfg.MetricsPort = c.MetricsPort
+	cfg.AuditLog = c.AuditLog
+	return cfg, nil
+}
+
+// load builds a configuration from the built-in defaults, the named profile
+// of file (if any) and env, in increasing order of precedence.
+func load(env *environment, file *File, profileName string) (*APIConfig, error) {
+	cfg := &APIConfig{
+		env: env,
+
+		ToolTimeouts:    make(map[string]time.Duration),
+		MaxRetries:      2,
+		RetryBaseDelay:  500 * time.Millisecond,
+		RetryMaxDelay:   10 * time.Second,
+		ReadyCacheTTL:   30 * time.Second,
+		ShutdownTimeout: 20 * time.Second,
+
+		ConfirmDestructive: true,
+	}
+
+	if file != nil {
+		profile, err := file.Profile(profileName)
+		if err != nil {
+			return nil, err
+		}
+		profile.apply(cfg)
+		cfg.File = file
+		cfg.Profile = profileName
+		if cfg.Profile == "" {
+			cfg.Profile = file.DefaultProfile
+		}
+	} else if profileName != "" {
+		return nil, fmt.Errorf("PROFILE is set to %q but no CONFIG_FILE is configured", profileName)
+	}
+
+	setString(&cfg.BaseURL, env.get("API_BASE_URL"))
+	setString(&cfg.AppID, env.get("APP_ID"))
+	setString(&cfg.ConsumerID, env.get("CONSUMER_ID"))
+	for name, field := range map[string]*string{
+		"BEARER_TOKEN":             &cfg.BearerToken,
+		"API_KEY":                  &cfg.APIKey,
+		"BASIC_AUTH":               &cfg.BasicAuth,
+		"DOWNSTREAM_AUTHORIZATION": &cfg.DownstreamAuthorization,
+	} {
+		secret, err := env.secret(name)
+		if err != nil {
+			return nil, err
+		}
+		setString(field, secret)
+	}
+
+	timeout, err := env.duration("TOOL_TIMEOUT", cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Timeout = timeout
+	toolTimeouts, err := parseToolTimeouts(env.get("TOOL_TIMEOUTS"))
+	if err != nil {
+		return nil, err
+	}
+	for name, timeout := range toolTimeouts {
+		cfg.ToolTimeouts[name] = timeout
+	}
+	if v := env.get("ENABLED_TOOLS"); v != "" {
+		cfg.EnabledTools = splitList(v)
+	}
+	if v := env.get("DISABLED_TOOLS"); v != "" {
+		cfg.DisabledTools = splitList(v)
+	}
+	if err := checkToolPatterns("enabled tools", cfg.EnabledTools); err != nil {
+		return nil, err
+	}
+	if err := checkToolPatterns("disabled tools", cfg.DisabledTools); err != nil {
+		return nil, err
+	}
+	if v := env.get("READ_ONLY"); v != "" {
+		cfg.ReadOnly = v == "true"
+	}
+	if v := env.get("CONFIRM_DESTRUCTIVE"); v != "" {
+		cfg.ConfirmDestructive = v == "true"
+	}
+	if v := env.get("DRY_RUN"); v != "" {
+		cfg.DryRun = v == "true"
+	}
+
+	if v := env.get("MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid MAX_RETRIES: %q", v)
+		}
+		cfg.MaxRetries = n
+	}
+	if cfg.RetryBaseDelay, err = env.duration("RETRY_BASE_DELAY", cfg.RetryBaseDelay); err != nil {
+		return nil, err
+	}
+	if cfg.RetryMaxDelay, err = env.duration("RETRY_MAX_DELAY", cfg.RetryMaxDelay); err != nil {
+		return nil, err
+	}
+	cfg.RetryNonIdempotent = env.get("RETRY_NON_IDEMPOTENT") == "true"
+	if cfg.ReadyCacheTTL, err = env.duration("READY_CACHE_TTL", cfg.ReadyCacheTTL); err != nil {
+		return nil, err
+	}
+	if cfg.ShutdownTimeout, err = env.duration("SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout); err != nil {
+		return nil, err
+	}
+
+	cfg.BaseURLPolicy = BaseURLPolicy{
+		Allowed:      splitList(env.get("ALLOWED_BASE_URLS")),
+		Configured:   cfg.BaseURL,
+		AllowHTTP:    env.get("ALLOW_HTTP_BASE_URLS") == "true",
+		AllowPrivate: env.get("ALLOW_PRIVATE_BASE_URLS") == "true",
+	}
+
+	return cfg, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// parseToolTimeouts parses a comma-separated list of tool=duration pairs,
+// e.g. "get_vault_logs=10s,post_vault_connections_unified_api_service_id_import=2m".
+func parseToolTimeouts(v string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid TOOL_TIMEOUTS entry %q: expected tool=duration", pair)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOOL_TIMEOUTS entry %q: %w", pair, err)
+		}
+		timeouts[strings.TrimSpace(name)] = d
+	}
+	return timeouts, nil
 }
 
 
@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestToolEnabled(t *testing.T) {
+	const (
+		list   = "get_vault_consumers"
+		delete = "delete_vault_consumers_consumer_id"
+		logs   = "get_vault_logs"
+	)
+	tests := []struct {
+		name     string
+		cfg      APIConfig
+		tool     string
+		category string
+		readOnly bool
+		enabled  bool
+	}{
+		{name: "no lists", tool: delete, category: "consumers", enabled: true},
+		{name: "by name", cfg: APIConfig{EnabledTools: []string{list}}, tool: list, category: "consumers", readOnly: true, enabled: true},
+		{name: "name not listed", cfg: APIConfig{EnabledTools: []string{list}}, tool: delete, category: "consumers"},
+		{name: "by pattern", cfg: APIConfig{EnabledTools: []string{"get_vault_*"}}, tool: logs, category: "logs", readOnly: true, enabled: true},
+		{name: "by category", cfg: APIConfig{EnabledTools: []string{"consumers"}}, tool: delete, category: "consumers", enabled: true},
+		{name: "other category", cfg: APIConfig{EnabledTools: []string{"consumers"}}, tool: logs, category: "logs", readOnly: true},
+		{name: "disabled by pattern", cfg: APIConfig{DisabledTools: []string{"delete_*"}}, tool: delete, category: "consumers"},
+		{
+			name:     "disabled wins",
+			cfg:      APIConfig{EnabledTools: []string{"consumers"}, DisabledTools: []string{list}},
+			tool:     list,
+			category: "consumers",
+			readOnly: true,
+		},
+		{name: "read-only mode", cfg: APIConfig{ReadOnly: true}, tool: delete, category: "consumers"},
+		{name: "read-only tool in read-only mode", cfg: APIConfig{ReadOnly: true}, tool: logs, category: "logs", readOnly: true, enabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ToolEnabled(tt.tool, tt.category, tt.readOnly); got != tt.enabled {
+				t.Errorf("ToolEnabled(%q, %q, %v) = %v, want %v", tt.tool, tt.category, tt.readOnly, got, tt.enabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries cfg, e.g. the configuration
+// resolved for an HTTP request.
+func NewContext(ctx context.Context, cfg *APIConfig) context.Context {
+	return context.WithValue(ctx, contextKey{}, cfg)
+}
+
+// FromContext returns the configuration carried by ctx, or fallback when ctx
+// carries none.
+func FromContext(ctx context.Context, fallback *APIConfig) *APIConfig {
+	if cfg, ok := ctx.Value(contextKey{}).(*APIConfig); ok {
+		return cfg
+	}
+	return fallback
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// environment resolves settings from the process environment and, as a
+// fallback, from the env-file named by ENV_FILE.
+type environment struct {
+	file map[string]string
+}
+
+func loadEnvironment() (*environment, error) {
+	env := &environment{}
+	if path := os.Getenv("ENV_FILE"); path != "" {
+		file, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		env.file = file
+	}
+	return env, nil
+}
+
+// get returns the named setting, or "" when it is set neither in the
+// environment nor in the env-file.
+func (e *environment) get(name string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return e.file[name]
+}
+
+// secret returns a credential. It is taken from the setting itself, else from
+// the file named by <name>_FILE, else from a file called <name> (or its lower
+// case form) in SECRETS_DIR, which is where Docker and Kubernetes mount
+// secrets. Surrounding whitespace, such as a trailing newline, is dropped
+// from files.
+func (e *environment) secret(name string) (string, error) {
+	if v := e.get(name); v != "" {
+		return v, nil
+	}
+	if path := e.get(name + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading %s_FILE: %w", name, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if dir := e.get("SECRETS_DIR"); dir != "" {
+		for _, file := range []string{name, strings.ToLower(name)} {
+			data, err := os.ReadFile(filepath.Join(dir, file))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return "", fmt.Errorf("reading secret %s: %w", name, err)
+			}
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", nil
+}
+
+// duration reads a duration such as "500ms" from the named setting,
+// returning def when it is not set.
+func (e *environment) duration(name string, def time.Duration) (time.Duration, error) {
+	v := e.get(name)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return d, nil
+}
+
+// readEnvFile parses a file of NAME=value lines. Blank lines and lines
+// starting with # are skipped, an "export " prefix is allowed, and values
+// may be wrapped in single or double quotes.
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ENV_FILE: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected NAME=value", path, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ENV_FILE: %w", err)
+	}
+	return values, nil
+}
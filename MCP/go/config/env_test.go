@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnvironmentSecret(t *testing.T) {
+	const name = "TEST_API_KEY"
+	dir := t.TempDir()
+	write := func(path, data string) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	keyFile := write(filepath.Join(dir, "key"), "  from-file\n")
+	secretsDir := filepath.Join(dir, "secrets")
+	write(filepath.Join(secretsDir, name), "from-secrets-dir\n")
+	lowerDir := filepath.Join(dir, "lower")
+	write(filepath.Join(lowerDir, "test_api_key"), "\tfrom-lower-case\n\n")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    map[string]string // the env-file
+		want    string
+		wantErr bool
+	}{
+		{name: "not set"},
+		{name: "environment", env: map[string]string{name: "from-env", name + "_FILE": keyFile}, want: "from-env"},
+		{name: "env-file", file: map[string]string{name: "from-env-file"}, want: "from-env-file"},
+		{name: "environment over env-file", env: map[string]string{name: "from-env"}, file: map[string]string{name: "from-env-file"}, want: "from-env"},
+		{name: "_FILE trimmed", env: map[string]string{name + "_FILE": keyFile}, want: "from-file"},
+		{name: "_FILE from the env-file", file: map[string]string{name + "_FILE": keyFile}, want: "from-file"},
+		{name: "_FILE over SECRETS_DIR", env: map[string]string{name + "_FILE": keyFile, "SECRETS_DIR": secretsDir}, want: "from-file"},
+		{name: "missing _FILE", env: map[string]string{name + "_FILE": filepath.Join(dir, "missing")}, wantErr: true},
+		{name: "SECRETS_DIR", env: map[string]string{"SECRETS_DIR": secretsDir}, want: "from-secrets-dir"},
+		{name: "SECRETS_DIR lower case", env: map[string]string{"SECRETS_DIR": lowerDir}, want: "from-lower-case"},
+		{name: "SECRETS_DIR without the secret", env: map[string]string{"SECRETS_DIR": dir}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range []string{name, name + "_FILE", "SECRETS_DIR"} {
+				t.Setenv(v, tt.env[v])
+			}
+			got, err := (&environment{file: tt.file}).secret(name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("secret(%s) error = %v, want error %t", name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("secret(%s) = %q, want %q", name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "plain values",
+			content: "API_KEY=sk_live_key\nAPP_ID = sandbox-app \n",
+			want:    map[string]string{"API_KEY": "sk_live_key", "APP_ID": "sandbox-app"},
+		},
+		{
+			name:    "comments and blank lines",
+			content: "# Vault\n\n  # indented comment\nAPP_ID=sandbox-app\n",
+			want:    map[string]string{"APP_ID": "sandbox-app"},
+		},
+		{
+			name:    "export",
+			content: "export API_KEY=sk_live_key\n  export APP_ID=sandbox-app\n",
+			want:    map[string]string{"API_KEY": "sk_live_key", "APP_ID": "sandbox-app"},
+		},
+		{
+			name:    "quoting",
+			content: "A=\"double quoted # kept\"\nB='single quoted'\nC=\"unbalanced'\nD=\"\"\nE=\"\n",
+			want:    map[string]string{"A": "double quoted # kept", "B": "single quoted", "C": "\"unbalanced'", "D": "", "E": "\""},
+		},
+		{
+			name:    "equals sign in the value",
+			content: "BASIC_AUTH=dXNlcjpwYXNz==\nDSN='a=b'\n",
+			want:    map[string]string{"BASIC_AUTH": "dXNlcjpwYXNz==", "DSN": "a=b"},
+		},
+		{name: "no equals sign", content: "APP_ID=sandbox-app\nAPI_KEY\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readEnvFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readEnvFile error = %v, want error %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readEnvFile = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
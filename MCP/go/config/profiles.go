@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a configuration file holding named profiles, e.g. one per Vault
+// application or environment. Both YAML and JSON files are accepted:
+//
+//	default_profile: sandbox
+//	profiles:
+//	  sandbox:
+//	    base_url: https://unify.apideck.com
+//	    api_key: sk_sandbox_...
+//	    app_id: sandbox-app
+//	    timeout: 30s
+//	  production:
+//	    base_url: https://unify.apideck.com
+//	    api_key: sk_live_...
+//	    app_id: production-app
+//	    enabled_tools: [consumers, get_vault_connections*]
+//	    disabled_tools: [delete_vault_*]
+//	  analyst:
+//	    base_url: https://unify.apideck.com
+//	    api_key: sk_live_...
+//	    read_only: true
+type File struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+
+	// Auth configures inbound authentication in HTTP mode.
+	Auth Auth `yaml:"auth"`
+}
+
+// Profile holds the settings of one named profile. Empty fields leave the
+// built-in default in place.
+type Profile struct {
+	BaseURL                 string `yaml:"base_url"`
+	APIKey                  string `yaml:"api_key"`
+	BearerToken             string `yaml:"bearer_token"`
+	BasicAuth               string `yaml:"basic_auth"`
+	DownstreamAuthorization string `yaml:"downstream_authorization"`
+
+	AppID      string `yaml:"app_id"`
+	ConsumerID string `yaml:"consumer_id"`
+
+	Timeout      time.Duration            `yaml:"timeout"`
+	ToolTimeouts map[string]time.Duration `yaml:"tool_timeouts"`
+
+	// EnabledTools and DisabledTools select the tools offered, by name,
+	// pattern or category; see APIConfig.EnabledTools.
+	EnabledTools  []string `yaml:"enabled_tools"`
+	DisabledTools []string `yaml:"disabled_tools"`
+
+	// ReadOnly offers only the tools that change nothing in Vault.
+	ReadOnly bool `yaml:"read_only"`
+
+	// DryRun makes tools that change data only report their request.
+	DryRun bool `yaml:"dry_run"`
+}
+
+// LoadFile reads a configuration file. Unknown keys are rejected so that a
+// misspelt setting does not go unnoticed.
+func LoadFile(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	defer f.Close()
+
+	var file File
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	if file.DefaultProfile != "" {
+		if _, ok := file.Profiles[file.DefaultProfile]; !ok {
+			return nil, fmt.Errorf("config file %s: default_profile %q is not defined", path, file.DefaultProfile)
+		}
+	}
+	return &file, nil
+}
+
+// Profile returns the named profile, or the default profile when name is
+// empty.
+func (f *File) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(f.names(), ", "))
+	}
+	return profile, nil
+}
+
+func (f *File) names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply copies the fields that are set in p to c.
+func (p Profile) apply(c *APIConfig) {
+	setString(&c.BaseURL, p.BaseURL)
+	setString(&c.APIKey, p.APIKey)
+	setString(&c.BearerToken, p.BearerToken)
+	setString(&c.BasicAuth, p.BasicAuth)
+	setString(&c.DownstreamAuthorization, p.DownstreamAuthorization)
+	setString(&c.AppID, p.AppID)
+	setString(&c.ConsumerID, p.ConsumerID)
+	if p.Timeout != 0 {
+		c.Timeout = p.Timeout
+	}
+	for name, timeout := range p.ToolTimeouts {
+		c.ToolTimeouts[name] = timeout
+	}
+	if len(p.EnabledTools) > 0 {
+		c.EnabledTools = p.EnabledTools
+	}
+	if len(p.DisabledTools) > 0 {
+		c.DisabledTools = p.DisabledTools
+	}
+	if p.ReadOnly {
+		c.ReadOnly = true
+	}
+	if p.DryRun {
+		c.DryRun = true
+	}
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
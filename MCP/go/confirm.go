@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/auth"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+	"github.com/vault-api/mcp-server/tools/common"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// confirmArgument carries the token that confirms a destructive call for
+// clients without elicitation support.
+const confirmArgument = "confirm"
+
+const (
+	confirmTokenTTL    = 5 * time.Minute // how long a confirm token is accepted
+	elicitationTimeout = 2 * time.Minute // how long to wait for the user's answer
+)
+
+// impactFunc describes what a destructive call is about to remove, from the
+// data Vault holds for the call's arguments.
+type impactFunc func(ctx context.Context, client *vault.Client, args map[string]any) (string, error)
+
+// confirmedTools are the tools that remove data for good. Calls to them go
+// through only once the user has confirmed them.
+var confirmedTools = map[string]impactFunc{
+	"delete_vault_consumers_consumer_id":                                  consumerDeleteImpact,
+	"delete_vault_connections_unified_api_service_id":                     connectionDeleteImpact,
+	"get_vault_revoke_service_id_application_id":                          revokeImpact,
+	"delete_vault_custom-mappings_unified_api_service_id_target_field_id": customMappingDeleteImpact,
+}
+
+// confirmations holds back calls to confirmedTools until the user agrees to
+// what they remove. Confirm tokens are HMACs of the tool name, its arguments
+// and an expiry time, under a key that lives as long as the process. A token
+// therefore confirms exactly the call that was summarized, and no state is
+// kept between the two calls.
+type confirmations struct {
+	key []byte
+}
+
+func newConfirmations() *confirmations {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return &confirmations{key: key}
+}
+
+// middleware summarizes the impact of a destructive call and asks the user
+// to confirm it through MCP elicitation. For clients that do not support
+// elicitation the call returns the summary with a token instead; repeating
+// the call with the token as "confirm" carries it out. It runs after the IDs
+// are defaulted and checked, so it summarizes the call that will be made.
+// Dry runs change nothing and need no confirmation.
+func (c *confirmations) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name := request.Params.Name
+			impact, ok := confirmedTools[name]
+			cfg := config.FromContext(ctx, nil)
+			if !ok || cfg == nil || !cfg.ConfirmDestructive || vault.IsDryRun(ctx) {
+				return next(ctx, request)
+			}
+
+			args, _ := request.Params.Arguments.(map[string]any)
+			token, _ := args[confirmArgument].(string)
+			args = maps.Clone(args)
+			delete(args, confirmArgument)
+			request.Params.Arguments = args
+			if token != "" {
+				if !c.valid(token, name, args) {
+					return mcp.NewToolResultError("The confirm token is invalid or has expired, or the arguments differ from the confirmed call. Call the tool without confirm to get a new token."), nil
+				}
+				return next(ctx, request)
+			}
+
+			summary, err := impact(ctx, vault.NewClient(cfg), args)
+			if err != nil {
+				return common.ErrorResult(err), nil
+			}
+			if !canElicit(ctx) {
+				return mcp.NewToolResultError(fmt.Sprintf(
+					"%s\n\nNothing has been changed. Show this to the user. Once they agree, call %s again with the same arguments and %q set to %q. The token is valid for %s.",
+					summary, name, confirmArgument, c.issue(name, args), confirmTokenTTL,
+				)), nil
+			}
+
+			confirmed, err := elicitConfirmation(ctx, summary)
+			switch {
+			case err != nil:
+				return mcp.NewToolResultError(fmt.Sprintf("Asking for confirmation failed: %v. Nothing has been changed.", err)), nil
+			case !confirmed:
+				return mcp.NewToolResultError("The user did not confirm. Nothing has been changed."), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// issue returns a token confirming a call of tool with args.
+func (c *confirmations) issue(tool string, args map[string]any) string {
+	return c.token(tool, args, time.Now().Add(confirmTokenTTL))
+}
+
+// valid reports whether token confirms a call of tool with args and has not
+// expired.
+func (c *confirmations) valid(token, tool string, args map[string]any) bool {
+	unix, _, _ := strings.Cut(token, ".")
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return false
+	}
+	expires := time.Unix(seconds, 0)
+	if time.Now().After(expires) {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(c.token(tool, args, expires)))
+}
+
+func (c *confirmations) token(tool string, args map[string]any, expires time.Time) string {
+	// Map keys are encoded in sorted order, so equal arguments encode alike.
+	encoded, _ := json.Marshal(args)
+	mac := hmac.New(sha256.New, c.key)
+	fmt.Fprintf(mac, "%s\n%d\n", tool, expires.Unix())
+	mac.Write(encoded)
+	return fmt.Sprintf("%d.%s", expires.Unix(), base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16]))
+}
+
+// canElicit reports whether the client of the session in ctx declared
+// elicitation support and its transport can carry the request.
+func canElicit(ctx context.Context) bool {
+	session := server.ClientSessionFromContext(ctx)
+	if _, ok := session.(server.SessionWithElicitation); !ok {
+		return false
+	}
+	info, ok := session.(server.SessionWithClientInfo)
+	return ok && info.GetClientCapabilities().Elicitation != nil
+}
+
+// elicitConfirmation shows summary to the user and reports whether they
+// agreed to go ahead.
+func elicitConfirmation(ctx context.Context, summary string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+	result, err := server.ServerFromContext(ctx).RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: summary + "\n\nDo you want to go ahead?",
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"confirm": map[string]any{
+						"type":        "boolean",
+						"title":       "Go ahead",
+						"description": "This cannot be undone.",
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+	content, _ := result.Content.(map[string]any)
+	confirmed, _ := content["confirm"].(bool)
+	return confirmed, nil
+}
+
+// withConfirmArgument adds the confirm argument to the schema of a tool in
+// confirmedTools.
+func withConfirmArgument(tool mcp.Tool) mcp.Tool {
+	properties := maps.Clone(tool.InputSchema.Properties)
+	properties[confirmArgument] = map[string]any{
+		"type":        "string",
+		"description": "Token returned by a previous call of this tool with the same arguments, passed once the user agreed to the change it summarized. Not needed when the client supports elicitation.",
+	}
+	tool.InputSchema.Properties = properties
+	return tool
+}
+
+func consumerDeleteImpact(ctx context.Context, client *vault.Client, args map[string]any) (string, error) {
+	var params vault.ConsumersOneParams
+	if err := common.BindArguments(args, &params); err != nil {
+		return "", err
+	}
+	resp, err := client.ConsumersOne(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	consumer := resp.Data
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deleting consumer %s", consumer.Consumer_id)
+	if who := consumerName(consumer.Metadata); who != "" {
+		fmt.Fprintf(&b, " (%s)", who)
+	}
+	b.WriteString(" also deletes all of its connections, including their credentials.")
+	if len(consumer.Connections) == 0 {
+		b.WriteString(" It has no connections.")
+	} else {
+		fmt.Fprintf(&b, " It has %d connection(s):", len(consumer.Connections))
+		for _, conn := range consumer.Connections {
+			fmt.Fprintf(&b, "\n- %s (%s/%s), state %s", conn.Name, conn.Unified_api, conn.Service_id, conn.State)
+			if !conn.Enabled {
+				b.WriteString(", disabled")
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// consumerName names a consumer by the metadata shown in Vault.
+func consumerName(m models.ConsumerMetadata) string {
+	names := slices.DeleteFunc([]string{m.User_name, m.Account_name, m.Email}, func(s string) bool { return s == "" })
+	return strings.Join(names, ", ")
+}
+
+func connectionDeleteImpact(ctx context.Context, client *vault.Client, args map[string]any) (string, error) {
+	var params vault.ConnectionsOneParams
+	if err := common.BindArguments(args, &params); err != nil {
+		return "", err
+	}
+	resp, err := client.ConnectionsOne(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	conn := resp.Data
+	return fmt.Sprintf("Deleting the %s connection (%s/%s) of consumer %s removes it together with its credentials and settings. It is in state %s and %s.",
+		conn.Name, conn.Unified_api, conn.Service_id, params.ConsumerID, conn.State, enabledText(conn.Enabled)), nil
+}
+
+func customMappingDeleteImpact(ctx context.Context, client *vault.Client, args map[string]any) (string, error) {
+	var params vault.CustomMappingsOneParams
+	if err := common.BindArguments(args, &params); err != nil {
+		return "", err
+	}
+	resp, err := client.CustomMappingsOne(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	mapping := resp.Data
+	return fmt.Sprintf("Deleting custom mapping %q (%s) of the %s/%s connection of consumer %s removes its mapping to %q.",
+		mapping.Label, mapping.Id, params.UnifiedAPI, params.ServiceID, params.ConsumerID, mapping.Value), nil
+}
+
+// revokeImpact looks up the connection named by the state token of the
+// revoke link. The lookup is best effort: the link stays valid when the
+// connection cannot be found from the token.
+func revokeImpact(ctx context.Context, client *vault.Client, args map[string]any) (string, error) {
+	var params vault.ConnectionsRevokeParams
+	if err := common.BindArguments(args, &params); err != nil {
+		return "", err
+	}
+	generic := fmt.Sprintf("Revoking the %s connection named by the state token removes its OAuth tokens. It has to be authorized again before it can be used.", params.ServiceID)
+
+	consumerID, unifiedAPI := stateClaims(params.State)
+	if consumerID == "" || unifiedAPI == "" {
+		return generic, nil
+	}
+	if id := auth.FromContext(ctx); id != nil && !id.AllowsConsumer(consumerID) {
+		return generic, nil
+	}
+	resp, err := client.ConnectionsOne(ctx, vault.ConnectionsOneParams{
+		ConsumerID: consumerID,
+		AppID:      params.ApplicationID,
+		ServiceID:  params.ServiceID,
+		UnifiedAPI: unifiedAPI,
+	})
+	if err != nil {
+		return generic, nil
+	}
+	conn := resp.Data
+	return fmt.Sprintf("Revoking the %s connection (%s/%s) of consumer %s removes its OAuth tokens. It is in state %s and %s, and has to be authorized again before it can be used.",
+		conn.Name, conn.Unified_api, conn.Service_id, consumerID, conn.State, enabledText(conn.Enabled)), nil
+}
+
+// stateClaims reads the consumer and unified API from the payload of the
+// JWT state token of an authorize or revoke link. The token is not verified
+// here; Vault does that when the link is used.
+func stateClaims(state string) (consumerID, unifiedAPI string) {
+	parts := strings.Split(state, ".")
+	if len(parts) != 3 {
+		return "", ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ""
+	}
+	var claims struct {
+		ConsumerID string `json:"consumer_id"`
+		UnifiedAPI string `json:"unified_api"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", ""
+	}
+	return claims.ConsumerID, claims.UnifiedAPI
+}
+
+func enabledText(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+const deleteConsumerTool = "delete_vault_consumers_consumer_id"
+
+func TestConfirmTokenValid(t *testing.T) {
+	c := newConfirmations()
+	args := map[string]any{"consumer_id": "test-42", appIDArgument: "app"}
+	token := c.issue(deleteConsumerTool, args)
+	unix, mac, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		tool  string
+		args  map[string]any
+		valid bool
+	}{
+		{name: "same call", token: token, tool: deleteConsumerTool, args: map[string]any{appIDArgument: "app", "consumer_id": "test-42"}, valid: true},
+		{name: "different arguments", token: token, tool: deleteConsumerTool, args: map[string]any{"consumer_id": "test-43", appIDArgument: "app"}},
+		{name: "extra argument", token: token, tool: deleteConsumerTool, args: map[string]any{"consumer_id": "test-42", appIDArgument: "app", "force": true}},
+		{name: "different tool", token: token, tool: "delete_vault_connections_unified_api_service_id", args: args},
+		{name: "expired", token: c.token(deleteConsumerTool, args, time.Now().Add(-time.Second)), tool: deleteConsumerTool, args: args},
+		{name: "forged expiry", token: fmt.Sprintf("%d.%s", time.Now().Add(time.Hour).Unix(), mac), tool: deleteConsumerTool, args: args},
+		{name: "no expiry", token: mac, tool: deleteConsumerTool, args: args},
+		{name: "expiry only", token: unix, tool: deleteConsumerTool, args: args},
+		{name: "other key", token: newConfirmations().issue(deleteConsumerTool, args), tool: deleteConsumerTool, args: args},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.valid(tt.token, tt.tool, tt.args); got != tt.valid {
+				t.Errorf("valid(%q) = %t, want %t", tt.token, got, tt.valid)
+			}
+		})
+	}
+}
+
+// confirmTokenPattern finds the confirm token in the summary returned to
+// clients without elicitation.
+var confirmTokenPattern = regexp.MustCompile(`"confirm" set to "([^"]+)"`)
+
+func TestConfirmationsMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/vault/consumers/test-42" {
+			t.Errorf("unexpected Vault request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":{"consumer_id":"test-42","metadata":{"user_name":"Ada"},"connections":[{"name":"Salesforce","unified_api":"crm","service_id":"salesforce","state":"callable","enabled":true}]}}`))
+	}))
+	defer srv.Close()
+
+	var calls []map[string]any
+	handler := newConfirmations().middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls = append(calls, request.GetArguments())
+		return mcp.NewToolResultText("deleted"), nil
+	})
+	ctx := config.NewContext(context.Background(), &config.APIConfig{BaseURL: srv.URL, ConfirmDestructive: true})
+	call := func(ctx context.Context, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		var request mcp.CallToolRequest
+		request.Params.Name = deleteConsumerTool
+		request.Params.Arguments = args
+		result, err := handler(ctx, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	args := map[string]any{"consumer_id": "test-42", appIDArgument: "app"}
+
+	result := call(ctx, args)
+	summary := resultText(result)
+	if !result.IsError || len(calls) != 0 {
+		t.Fatalf("call without confirm went ahead: %s", summary)
+	}
+	if !strings.Contains(summary, "Deleting consumer test-42 (Ada)") || !strings.Contains(summary, "Salesforce (crm/salesforce)") {
+		t.Errorf("summary does not describe the consumer: %s", summary)
+	}
+	match := confirmTokenPattern.FindStringSubmatch(summary)
+	if match == nil {
+		t.Fatalf("no confirm token in %s", summary)
+	}
+	token := match[1]
+
+	for name, args := range map[string]map[string]any{
+		"invalid token":       {"consumer_id": "test-42", appIDArgument: "app", confirmArgument: "123.abc"},
+		"different arguments": {"consumer_id": "test-43", appIDArgument: "app", confirmArgument: token},
+	} {
+		if result := call(ctx, args); !result.IsError || len(calls) != 0 {
+			t.Fatalf("%s: call went ahead: %s", name, resultText(result))
+		}
+	}
+
+	result = call(ctx, map[string]any{"consumer_id": "test-42", appIDArgument: "app", confirmArgument: token})
+	if result.IsError || len(calls) != 1 {
+		t.Fatalf("confirmed call did not go ahead: %s", resultText(result))
+	}
+	if _, ok := calls[0][confirmArgument]; ok {
+		t.Errorf("confirm passed on to the tool: %v", calls[0])
+	}
+
+	dryCtx, _ := vault.WithDryRun(ctx)
+	if result := call(dryCtx, args); result.IsError || len(calls) != 2 {
+		t.Errorf("dry run was held back for confirmation: %s", resultText(result))
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/config"
+)
+
+// Tool arguments that can be defaulted from configuration.
+const (
+	appIDArgument      = "x-apideck-app-id"
+	consumerIDArgument = "x-apideck-consumer-id"
+)
+
+const setCurrentConsumerName = "set_current_consumer"
+
+// sessionConsumers remembers the consumer selected with set_current_consumer
+// for each MCP session. In HTTP mode a selection holds for all later requests
+// carrying the same Mcp-Session-Id.
+type sessionConsumers struct {
+	mu  sync.Mutex
+	ids map[string]string // consumer ID by session ID
+}
+
+func newSessionConsumers() *sessionConsumers {
+	return &sessionConsumers{ids: make(map[string]string)}
+}
+
+// get returns the current consumer of the session in ctx, or "" if none is set.
+func (s *sessionConsumers) get(ctx context.Context) string {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[session.SessionID()]
+}
+
+// set makes consumerID the current consumer of the session in ctx; an empty
+// consumerID clears it. It reports false when ctx carries no session.
+func (s *sessionConsumers) set(ctx context.Context, consumerID string) bool {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if consumerID == "" {
+		delete(s.ids, session.SessionID())
+	} else {
+		s.ids[session.SessionID()] = consumerID
+	}
+	return true
+}
+
+// forget drops the current consumer of a session that has ended.
+func (s *sessionConsumers) forget(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ids, sessionID)
+}
+
+// hooks returns server hooks that forget the current consumer of each
+// session the server unregisters.
+func (s *sessionConsumers) hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.forget(session.SessionID())
+	})
+	return hooks
+}
+
+// sessionIDs issues the session IDs of the streamable HTTP server. Sessions
+// that the client ends with DELETE are not unregistered from the MCP server,
+// so their current consumer is forgotten here.
+type sessionIDs struct {
+	server.InsecureStatefulSessionIdManager
+	consumers *sessionConsumers
+}
+
+func (m *sessionIDs) Terminate(sessionID string) (bool, error) {
+	m.consumers.forget(sessionID)
+	return m.InsecureStatefulSessionIdManager.Terminate(sessionID)
+}
+
+// withDefaultIDs fills in the app ID and consumer ID arguments that a call
+// leaves out, for tools that take them. An argument supplied by the caller
+// always wins; a missing consumer ID comes from the session's current
+// consumer first and from the configured default second.
+func withDefaultIDs(catalog toolCatalog, consumers *sessionConsumers) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args, ok := request.Params.Arguments.(map[string]any)
+			tool, known := catalog[request.Params.Name]
+			cfg := config.FromContext(ctx, nil)
+			if !ok || !known || cfg == nil {
+				return next(ctx, request)
+			}
+
+			defaults := map[string]string{
+				appIDArgument:      cfg.AppID,
+				consumerIDArgument: consumers.get(ctx),
+			}
+			if defaults[consumerIDArgument] == "" {
+				defaults[consumerIDArgument] = cfg.ConsumerID
+			}
+
+			// Copy before filling in so the caller's arguments are not modified.
+			args = maps.Clone(args)
+			for name, value := range defaults {
+				if _, takes := tool.Definition.InputSchema.Properties[name]; value == "" || !takes {
+					continue
+				}
+				if v, ok := args[name]; !ok || v == nil || v == "" {
+					args[name] = value
+				}
+			}
+			request.Params.Arguments = args
+			return next(ctx, request)
+		}
+	}
+}
+
+// withDefaultArguments relaxes the schema of a tool whose app ID or consumer
+// ID arguments have a default, from the configuration or the session's
+// current consumer: they are no longer required, and their description names
+// the value used when they are omitted.
+func withDefaultArguments(tool mcp.Tool, appID, consumerID string) mcp.Tool {
+	defaults := map[string]string{
+		appIDArgument:      appID,
+		consumerIDArgument: consumerID,
+	}
+
+	properties := maps.Clone(tool.InputSchema.Properties)
+	required := slices.Clone(tool.InputSchema.Required)
+	for name, value := range defaults {
+		property, ok := properties[name].(map[string]any)
+		if value == "" || !ok {
+			continue
+		}
+		property = maps.Clone(property)
+		description, _ := property["description"].(string)
+		property["description"] = fmt.Sprintf("%s. Defaults to %q when omitted.", strings.TrimSuffix(description, "."), value)
+		properties[name] = property
+		required = slices.DeleteFunc(required, func(r string) bool { return r == name })
+	}
+	tool.InputSchema.Properties = properties
+	tool.InputSchema.Required = required
+	return tool
+}
+
+// setCurrentConsumerTool lets an agent pick the consumer once per session
+// instead of passing x-apideck-consumer-id on every call.
+func setCurrentConsumerTool(consumers *sessionConsumers) server.ServerTool {
+	tool := mcp.NewTool(setCurrentConsumerName,
+		mcp.WithDescription("Set the consumer used by later tool calls in this session that omit x-apideck-consumer-id. Pass an empty consumer_id to clear it."),
+		// It only changes this session's defaults, nothing in Vault.
+		mcp.WithTitleAnnotation("Set current consumer"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("consumer_id", mcp.Required(), mcp.Description("ID of the consumer to use for this session, or empty to clear")),
+	)
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		consumerID := request.GetString("consumer_id", "")
+		if !consumers.set(ctx, consumerID) {
+			return mcp.NewToolResultError("No MCP session: the current consumer cannot be remembered"), nil
+		}
+		// The consumer ID argument is required or not depending on the
+		// current consumer, so the session's tool list has changed.
+		if srv := server.ServerFromContext(ctx); srv != nil {
+			if err := srv.SendNotificationToClient(ctx, mcp.MethodNotificationToolsListChanged, nil); err != nil {
+				log.Printf("Notifying session of changed tools failed: %v", err)
+			}
+		}
+		if consumerID == "" {
+			return mcp.NewToolResultText("Current consumer cleared"), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Current consumer set to %q", consumerID)), nil
+	}
+	return server.ServerTool{Tool: tool, Handler: handler}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+)
+
+// testSession is an initialized client session that collects the
+// notifications sent to it.
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newTestSession(id string) *testSession {
+	return &testSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10)}
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return s.id }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// handle sends a JSON-RPC request to srv in session and decodes its result
+// into out.
+func handle(t *testing.T, srv *server.MCPServer, session server.ClientSession, method string, params any, out any) {
+	t.Helper()
+	handleContext(t, srv, srv.WithContext(context.Background(), session), method, params, out)
+}
+
+// handleContext sends a JSON-RPC request to srv with ctx, which carries the
+// session, and decodes its result into out.
+func handleContext(t *testing.T, srv *server.MCPServer, ctx context.Context, method string, params any, out any) {
+	t.Helper()
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := json.Marshal(srv.HandleMessage(ctx, message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+		Error  any             `json:"error"`
+	}
+	if err := json.Unmarshal(response, &decoded); err != nil || decoded.Error != nil {
+		t.Fatalf("%s: %s", method, response)
+	}
+	if err := json.Unmarshal(decoded.Result, out); err != nil {
+		t.Fatalf("%s: decoding %s: %v", method, decoded.Result, err)
+	}
+}
+
+func TestCurrentConsumerRelaxesToolList(t *testing.T) {
+	var current atomic.Pointer[config.APIConfig]
+	current.Store(&config.APIConfig{})
+	tool := mcp.NewTool("get_vault_connections",
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString(consumerIDArgument, mcp.Required(), mcp.Description("ID of the consumer")),
+	)
+	catalog := newToolCatalog([]models.Tool{{Definition: tool}})
+	consumers := newSessionConsumers()
+	srv := server.NewMCPServer("test", "1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithToolFilter(toolsFor(&current, catalog, consumers)),
+	)
+	srv.AddTool(tool, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	srv.AddTools(setCurrentConsumerTool(consumers))
+	session, other := newTestSession("s1"), newTestSession("s2")
+
+	required := func(session server.ClientSession) bool {
+		var result mcp.ListToolsResult
+		handle(t, srv, session, "tools/list", map[string]any{}, &result)
+		for _, listed := range result.Tools {
+			if listed.Name == tool.Name {
+				return slices.Contains(listed.InputSchema.Required, consumerIDArgument)
+			}
+		}
+		t.Fatalf("%s not listed", tool.Name)
+		return false
+	}
+	if !required(session) {
+		t.Fatal("consumer ID not required before a current consumer is set")
+	}
+
+	var result mcp.CallToolResult
+	handle(t, srv, session, "tools/call", map[string]any{"name": setCurrentConsumerName, "arguments": map[string]any{"consumer_id": "test-42"}}, &result)
+	if result.IsError {
+		t.Fatalf("set_current_consumer: %+v", result.Content)
+	}
+	select {
+	case n := <-session.notifications:
+		if n.Method != mcp.MethodNotificationToolsListChanged {
+			t.Errorf("notification %s, want %s", n.Method, mcp.MethodNotificationToolsListChanged)
+		}
+	default:
+		t.Error("no tools/list_changed notification sent to the session")
+	}
+	if len(other.notifications) != 0 {
+		t.Error("another session was notified")
+	}
+
+	if required(session) {
+		t.Error("consumer ID still required once the session has a current consumer")
+	}
+	if !required(other) {
+		t.Error("consumer ID not required in another session")
+	}
+}
+
+func TestEndedSessionsForgetTheirConsumer(t *testing.T) {
+	consumers := newSessionConsumers()
+	srv := server.NewMCPServer("test", "1.0.0", server.WithHooks(consumers.hooks()))
+	ids := &sessionIDs{consumers: consumers}
+
+	unregistered, terminated, open := newTestSession("s1"), newTestSession(ids.Generate()), newTestSession("s3")
+	for _, session := range []*testSession{unregistered, terminated, open} {
+		ctx := srv.WithContext(context.Background(), session)
+		if err := srv.RegisterSession(ctx, session); err != nil {
+			t.Fatal(err)
+		}
+		consumers.set(ctx, "test-42")
+	}
+
+	srv.UnregisterSession(context.Background(), unregistered.SessionID())
+	if _, err := ids.Terminate(terminated.SessionID()); err != nil {
+		t.Fatal(err)
+	}
+
+	consumers.mu.Lock()
+	defer consumers.mu.Unlock()
+	if want := map[string]string{open.SessionID(): "test-42"}; !maps.Equal(consumers.ids, want) {
+		t.Errorf("current consumers = %v, want %v", consumers.ids, want)
+	}
+}
+
+func TestWithDefaultIDsFillsDeclaredArguments(t *testing.T) {
+	cfg := &config.APIConfig{AppID: "sandbox-app", ConsumerID: "default-consumer"}
+	catalog := newToolCatalog(GetAll(cfg))
+	var got map[string]any
+	handler := withDefaultIDs(catalog, newSessionConsumers())(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		got = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	tests := []struct {
+		tool string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			tool: "get_vault_connections",
+			args: map[string]any{},
+			want: map[string]any{appIDArgument: "sandbox-app", consumerIDArgument: "default-consumer"},
+		},
+		{
+			tool: "get_vault_connections",
+			args: map[string]any{consumerIDArgument: "test-1"},
+			want: map[string]any{appIDArgument: "sandbox-app", consumerIDArgument: "test-1"},
+		},
+		{
+			tool: "delete_vault_consumers_consumer_id",
+			args: map[string]any{"consumer_id": "victim"},
+			want: map[string]any{appIDArgument: "sandbox-app", "consumer_id": "victim"},
+		},
+		{
+			tool: "get_vault_revoke_service_id_application_id",
+			args: map[string]any{"service_id": "salesforce"},
+			want: map[string]any{"service_id": "salesforce"},
+		},
+		{
+			tool: setCurrentConsumerName,
+			args: map[string]any{"consumer_id": "test-1"},
+			want: map[string]any{"consumer_id": "test-1"},
+		},
+	}
+	for _, tt := range tests {
+		var request mcp.CallToolRequest
+		request.Params.Name = tt.tool
+		request.Params.Arguments = tt.args
+		if _, err := handler(config.NewContext(context.Background(), cfg), request); err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(got, tt.want) {
+			t.Errorf("%s%v: arguments = %v, want %v", tt.tool, tt.args, got, tt.want)
+		}
+	}
+}
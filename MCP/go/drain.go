@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// cancelGrace is how long drain waits for interrupted tool calls to return,
+// so that their results still reach the clients.
+const cancelGrace = 2 * time.Second
+
+// errShuttingDown is the cause of the cancellation of tool calls interrupted
+// by a shutdown. It wraps context.Canceled, so that they are reported like
+// any other cancelled call.
+var errShuttingDown = fmt.Errorf("server is shutting down: %w", context.Canceled)
+
+// toolCalls tracks the running tool calls so that a shutdown can wait for
+// them instead of abandoning Vault mutations halfway.
+type toolCalls struct {
+	mu       sync.Mutex
+	draining bool
+	running  map[*toolCall]struct{}
+	wg       sync.WaitGroup
+}
+
+type toolCall struct {
+	name    string
+	started time.Time
+	cancel  context.CancelCauseFunc
+}
+
+func newToolCalls() *toolCalls {
+	return &toolCalls{running: make(map[*toolCall]struct{})}
+}
+
+// middleware registers each call for the time it runs. Once draining has
+// begun, new calls are rejected without being started.
+func (c *toolCalls) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, cancel := context.WithCancelCause(ctx)
+			defer cancel(nil)
+			call := &toolCall{name: request.Params.Name, started: time.Now(), cancel: cancel}
+
+			c.mu.Lock()
+			if c.draining {
+				c.mu.Unlock()
+				return mcp.NewToolResultError("Server is shutting down, the tool call was not started"), nil
+			}
+			c.running[call] = struct{}{}
+			c.wg.Add(1)
+			c.mu.Unlock()
+
+			defer func() {
+				c.mu.Lock()
+				delete(c.running, call)
+				c.mu.Unlock()
+				c.wg.Done()
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// isDraining reports whether drain has been called.
+func (c *toolCalls) isDraining() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.draining
+}
+
+// drain stops new tool calls from starting and waits up to timeout for the
+// running ones. Calls still running then are cancelled and logged.
+func (c *toolCalls) drain(timeout time.Duration) {
+	c.mu.Lock()
+	c.draining = true
+	n := len(c.running)
+	c.mu.Unlock()
+	if n == 0 {
+		return
+	}
+
+	log.Printf("Waiting up to %s for %d running tool call(s)", timeout, n)
+	if c.wait(timeout) {
+		log.Println("All tool calls finished")
+		return
+	}
+
+	c.mu.Lock()
+	for call := range c.running {
+		log.Printf("Interrupting tool call %s after %s", call.name, time.Since(call.started).Round(time.Millisecond))
+		call.cancel(errShuttingDown)
+	}
+	c.mu.Unlock()
+	if !c.wait(cancelGrace) {
+		log.Printf("Tool calls did not return within %s of being interrupted", cancelGrace)
+	}
+}
+
+// wait reports whether all running calls returned within timeout.
+func (c *toolCalls) wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
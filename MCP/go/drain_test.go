@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDrain(t *testing.T) {
+	calls := newToolCalls()
+	started := make(chan string, 2)
+	release := make(chan struct{})
+	handler := calls.middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started <- request.Params.Name
+		if request.Params.Name == "quick" {
+			// Finishes while draining, before the timeout.
+			<-release
+			if err := context.Cause(ctx); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText("done"), nil
+		}
+		// Runs until it is interrupted.
+		<-ctx.Done()
+		return nil, context.Cause(ctx)
+	})
+	type outcome struct {
+		result *mcp.CallToolResult
+		err    error
+	}
+	call := func(name string) <-chan outcome {
+		out := make(chan outcome, 1)
+		go func() {
+			var request mcp.CallToolRequest
+			request.Params.Name = name
+			result, err := handler(context.Background(), request)
+			out <- outcome{result, err}
+		}()
+		return out
+	}
+
+	quick, stuck := call("quick"), call("stuck")
+	<-started
+	<-started
+
+	drained := make(chan struct{})
+	go func() {
+		calls.drain(100 * time.Millisecond)
+		close(drained)
+	}()
+	for !calls.isDraining() {
+		time.Sleep(time.Millisecond)
+	}
+
+	refused := <-call("new")
+	if refused.err != nil || refused.result == nil || !refused.result.IsError {
+		t.Errorf("call while draining = %+v, want it refused", refused)
+	}
+	select {
+	case name := <-started:
+		t.Errorf("%s started while draining", name)
+	default:
+	}
+
+	close(release)
+	if got := <-quick; got.err != nil || got.result.IsError {
+		t.Errorf("running call = %+v, %v; want it to finish undisturbed", got.result, got.err)
+	}
+	select {
+	case <-drained:
+		t.Fatal("drain returned while a call was still running")
+	default:
+	}
+
+	got := <-stuck
+	if !errors.Is(got.err, errShuttingDown) || !errors.Is(got.err, context.Canceled) {
+		t.Errorf("interrupted call error = %v, want %v", got.err, errShuttingDown)
+	}
+	select {
+	case <-drained:
+	case <-time.After(cancelGrace):
+		t.Error("drain did not return after the interrupted call")
+	}
+}
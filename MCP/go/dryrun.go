@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// dryRunArgument asks a tool that changes data to only show the request it
+// would send.
+const dryRunArgument = "dry_run"
+
+// dryRunReport is the result of a dry run.
+type dryRunReport struct {
+	DryRun   bool                   `json:"dry_run"`
+	Tool     string                 `json:"tool"`
+	Valid    bool                   `json:"valid"`
+	Problems []string               `json:"problems,omitempty"`
+	Request  *vault.PreparedRequest `json:"request,omitempty"`
+}
+
+// withDryRun lets calls to tools that change data run without contacting
+// Vault, when they pass dry_run or the configuration sets DryRun. The tool
+// prepares its request as usual; the call returns that request, with
+// credentials redacted, and the problems found with the arguments. It runs
+// after the IDs are defaulted and checked, so it shows the request that a
+// real call would send. The result carries dry_run in _meta, which marks it
+// in the audit log.
+func withDryRun(catalog toolCatalog) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := catalog[request.Params.Name]
+			cfg := config.FromContext(ctx, nil)
+			if !ok || cfg == nil || isReadOnly(tool.Definition) {
+				return next(ctx, request)
+			}
+			args, _ := request.Params.Arguments.(map[string]any)
+			requested, isBool := args[dryRunArgument].(bool)
+			if value, ok := args[dryRunArgument]; ok {
+				// Anything but a boolean may have been meant as a dry run,
+				// so it must not go ahead as a real call.
+				if !isBool {
+					encoded, _ := json.Marshal(value)
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid argument %q: %s is not a boolean. Nothing has been changed.", dryRunArgument, encoded)), nil
+				}
+				args = maps.Clone(args)
+				delete(args, dryRunArgument)
+				request.Params.Arguments = args
+			}
+			if !requested && !cfg.DryRun {
+				return next(ctx, request)
+			}
+
+			report := dryRunReport{
+				DryRun:   true,
+				Tool:     request.Params.Name,
+				Problems: validateArguments(tool.Definition, args),
+			}
+			ctx, dry := vault.WithDryRun(ctx)
+			result, err := next(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			if prepared := dry.Requests(); len(prepared) > 0 {
+				report.Request = &prepared[0]
+			} else if result != nil && result.IsError {
+				// The handler rejected the arguments before preparing a request.
+				report.Problems = append(report.Problems, resultText(result))
+			}
+			report.Valid = len(report.Problems) == 0 && report.Request != nil
+
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to format dry run", err), nil
+			}
+			return &mcp.CallToolResult{
+				Result:  mcp.Result{Meta: mcp.NewMetaFromMap(map[string]any{dryRunArgument: true})},
+				Content: []mcp.Content{mcp.NewTextContent(string(out))},
+				IsError: !report.Valid,
+			}, nil
+		}
+	}
+}
+
+// isDryRun reports whether result is the result of a dry run.
+func isDryRun(result *mcp.CallToolResult) bool {
+	if result == nil || result.Meta == nil {
+		return false
+	}
+	dryRun, _ := result.Meta.AdditionalFields[dryRunArgument].(bool)
+	return dryRun
+}
+
+// isReadOnly reports whether tool is annotated as changing nothing.
+func isReadOnly(tool mcp.Tool) bool {
+	readOnly := tool.Annotations.ReadOnlyHint
+	return readOnly != nil && *readOnly
+}
+
+// withDryRunArgument adds the dry_run argument to the schema of a tool that
+// changes data.
+func withDryRunArgument(tool mcp.Tool) mcp.Tool {
+	properties := maps.Clone(tool.InputSchema.Properties)
+	properties[dryRunArgument] = map[string]any{
+		"type":        "boolean",
+		"description": "Only return the request this call would send to Vault, with credentials redacted, and the problems found with the arguments. Nothing is changed.",
+	}
+	tool.InputSchema.Properties = properties
+	return tool
+}
+
+// validateArguments checks args against the input schema of tool: required
+// arguments have to be present, and all arguments known and of their type.
+func validateArguments(tool mcp.Tool, args map[string]any) []string {
+	var problems []string
+	for _, name := range tool.InputSchema.Required {
+		if v, ok := args[name]; !ok || v == nil || v == "" {
+			problems = append(problems, fmt.Sprintf("missing required argument %q", name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		if name == confirmArgument {
+			continue
+		}
+		property, ok := tool.InputSchema.Properties[name].(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown argument %q", name))
+			continue
+		}
+		want, _ := property["type"].(string)
+		if v := args[name]; v != nil && !hasJSONType(v, want) {
+			problems = append(problems, fmt.Sprintf("argument %q should be of type %s", name, want))
+		}
+	}
+	return problems
+}
+
+// hasJSONType reports whether v, as decoded from JSON, is of the JSON schema
+// type want. Any value matches an empty type.
+func hasJSONType(v any, want string) bool {
+	switch want {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+)
+
+func TestWithDryRunRejectsNonBoolean(t *testing.T) {
+	catalog := toolCatalog{"delete_thing": models.Tool{Definition: mcp.NewTool("delete_thing")}}
+	handler := withDryRun(catalog)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t.Errorf("tool called with dry_run = %v", request.GetArguments()[dryRunArgument])
+		return mcp.NewToolResultText("deleted"), nil
+	})
+	ctx := config.NewContext(context.Background(), &config.APIConfig{})
+
+	for _, value := range []any{"true", 1.0, "false", nil} {
+		var request mcp.CallToolRequest
+		request.Params.Name = "delete_thing"
+		request.Params.Arguments = map[string]any{"id": "t1", dryRunArgument: value}
+		result, err := handler(ctx, request)
+		if err != nil {
+			t.Fatalf("dry_run = %#v: %v", value, err)
+		}
+		if !result.IsError {
+			t.Errorf("dry_run = %#v: result is not an error", value)
+		}
+	}
+}
+
+func TestDryRunIsAudited(t *testing.T) {
+	var buf bytes.Buffer
+	audit := &auditLog{w: &buf}
+	catalog := toolCatalog{"delete_thing": models.Tool{Definition: mcp.NewTool("delete_thing")}}
+	handler := audit.middleware(catalog)(withDryRun(catalog)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("deleted"), nil
+	}))
+
+	for _, cfg := range []*config.APIConfig{{}, {DryRun: true}} {
+		buf.Reset()
+		var request mcp.CallToolRequest
+		request.Params.Name = "delete_thing"
+		request.Params.Arguments = map[string]any{"id": "t1", dryRunArgument: !cfg.DryRun}
+		if _, err := handler(config.NewContext(context.Background(), cfg), request); err != nil {
+			t.Fatal(err)
+		}
+		var entry auditEntry
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("audit line %q: %v", buf.String(), err)
+		}
+		if !entry.DryRun {
+			t.Errorf("DRY_RUN=%t: audit entry %s has no dry_run marker", cfg.DryRun, buf.String())
+		}
+	}
+}
+
+func TestDryRunOfGeneratedTools(t *testing.T) {
+	cfg := &config.APIConfig{BaseURL: "https://unify.example.com", AppID: "sandbox-app", ConsumerID: "default-consumer", ConfirmDestructive: true}
+	var current atomic.Pointer[config.APIConfig]
+	current.Store(cfg)
+	srv := createMCPServer(&current, newToolCatalog(GetAll(cfg)), newSessionConsumers(), newToolCalls(), newMetrics(), nil)
+	ctx := srv.WithContext(context.Background(), newTestSession("s1"))
+
+	tests := []struct {
+		tool string
+		args map[string]any
+		url  string
+	}{
+		{
+			tool: "post_vault_consumers",
+			args: map[string]any{"consumer_id": "test-42", "metadata": map[string]any{"user_name": "Ada"}},
+			url:  "https://unify.example.com/vault/consumers",
+		},
+		{
+			tool: "get_vault_revoke_service_id_application_id",
+			args: map[string]any{"service_id": "salesforce", "application_id": "sandbox-app", "state": "eyJhbGciOiJIUzI1NiJ9.e30.sig", "redirect_uri": "https://example.com/done"},
+			url:  "https://unify.example.com/vault/revoke/salesforce/sandbox-app?state=[REDACTED]&redirect_uri=https%3A%2F%2Fexample.com%2Fdone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			tt.args[dryRunArgument] = true
+			var result mcp.CallToolResult
+			handleContext(t, srv, ctx, "tools/call", map[string]any{"name": tt.tool, "arguments": tt.args}, &result)
+			var report dryRunReport
+			if err := json.Unmarshal([]byte(resultText(&result)), &report); err != nil {
+				t.Fatalf("dry run result %q: %v", resultText(&result), err)
+			}
+			if result.IsError || !report.Valid || len(report.Problems) > 0 {
+				t.Errorf("dry run is not valid: %s", resultText(&result))
+			}
+			if report.Request == nil || report.Request.URL != tt.url {
+				t.Errorf("request = %+v, want URL %s", report.Request, tt.url)
+			}
+		})
+	}
+}
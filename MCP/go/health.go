@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// readyCheckTimeout bounds the Vault call made by a readiness check.
+const readyCheckTimeout = 5 * time.Second
+
+// healthz reports that the process is up. It never calls Vault, so a Vault
+// outage does not get the server restarted.
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+// readiness checks that Vault is reachable with the server's configuration.
+// Results are cached for the configured time, so frequent probes result in
+// few Vault calls, and concurrent probes wait for a single check.
+type readiness struct {
+	current *atomic.Pointer[config.APIConfig]
+	calls   *toolCalls
+
+	mu        sync.Mutex
+	checked   *config.APIConfig // configuration of the cached result
+	checkedAt time.Time
+	err       error
+}
+
+// ServeHTTP answers 200 when the last check succeeded and 503 when it failed
+// or the server is shutting down.
+func (rd *readiness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rd.calls.isDraining() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "draining"})
+		return
+	}
+	cfg := rd.current.Load()
+	if cfg.BaseURL == "" {
+		// Without a configured base URL every request brings its own, so
+		// there is nothing to check ahead of time.
+		writeJSON(w, http.StatusOK, map[string]any{"status": "ok", "vault": "not checked: no API_BASE_URL configured"})
+		return
+	}
+	if cfg.AppID == "" {
+		// Vault rejects calls without an app ID, which then comes from the
+		// headers of each request.
+		writeJSON(w, http.StatusOK, map[string]any{"status": "ok", "vault": "not checked: no APP_ID configured"})
+		return
+	}
+
+	checkedAt, err := rd.check(r.Context(), cfg)
+	body := map[string]any{"status": "ok", "vault": "ok", "checked_at": checkedAt.UTC().Format(time.RFC3339)}
+	status := http.StatusOK
+	if err != nil {
+		body["status"] = "unavailable"
+		body["vault"] = err.Error()
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, body)
+}
+
+// check returns the cached result for cfg, or lists a single consumer to get
+// a fresh one. A reloaded configuration is always checked anew.
+func (rd *readiness) check(ctx context.Context, cfg *config.APIConfig) (time.Time, error) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	if rd.checked == cfg && time.Since(rd.checkedAt) < cfg.ReadyCacheTTL {
+		return rd.checkedAt, rd.err
+	}
+
+	// Other probes may be waiting for this check, so it does not end when
+	// the probe that started it goes away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), readyCheckTimeout)
+	defer cancel()
+	limit := 1
+	_, err := vault.NewClient(cfg).ConsumersAll(ctx, vault.ConsumersAllParams{AppID: cfg.AppID, Limit: &limit})
+
+	rd.checked, rd.checkedAt, rd.err = cfg, time.Now(), err
+	return rd.checkedAt, err
+}
+
+// version reports the API spec version the tools were generated from, the
+// build and the number of tools.
+func version(current *atomic.Pointer[config.APIConfig], catalog toolCatalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		cfg := current.Load()
+		enabled := 0
+		for name := range catalog {
+			if catalog.enabled(cfg, name) {
+				enabled++
+			}
+		}
+
+		body := map[string]any{
+			"name":          serverName,
+			"spec_version":  specVersion,
+			"tools":         len(catalog),
+			"enabled_tools": enabled,
+		}
+		if info, ok := debug.ReadBuildInfo(); ok {
+			build := map[string]string{
+				"go_version": info.GoVersion,
+				"module":     info.Main.Path,
+				"version":    info.Main.Version,
+			}
+			for _, s := range info.Settings {
+				switch s.Key {
+				case "vcs.revision", "vcs.time", "vcs.modified":
+					build[s.Key] = s.Value
+				}
+			}
+			body["build"] = build
+		}
+		writeJSON(w, http.StatusOK, body)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
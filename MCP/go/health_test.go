@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+func TestReadyz(t *testing.T) {
+	var requests atomic.Int32
+	vaultSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("x-apideck-app-id") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status_code":401,"error":"Unauthorized","type_name":"UnauthorizedError","message":"Missing application ID"}`))
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":[]}`))
+	}))
+	defer vaultSrv.Close()
+
+	tests := []struct {
+		name     string
+		cfg      config.APIConfig
+		status   int
+		vault    string
+		requests int32
+	}{
+		{name: "no base URL", cfg: config.APIConfig{AppID: "sandbox-app"}, status: http.StatusOK, vault: "not checked: no API_BASE_URL configured"},
+		{name: "no app ID", cfg: config.APIConfig{BaseURL: vaultSrv.URL}, status: http.StatusOK, vault: "not checked: no APP_ID configured"},
+		{name: "checked", cfg: config.APIConfig{BaseURL: vaultSrv.URL, AppID: "sandbox-app"}, status: http.StatusOK, vault: "ok", requests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			cfg := tt.cfg
+			cfg.ReadyCacheTTL = time.Minute
+			var current atomic.Pointer[config.APIConfig]
+			current.Store(&cfg)
+			rec := httptest.NewRecorder()
+			(&readiness{current: &current, calls: newToolCalls()}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != tt.status || body["vault"] != tt.vault {
+				t.Errorf("/readyz = %d %s, want %d with vault %q", rec.Code, rec.Body, tt.status, tt.vault)
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("sent %d Vault requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
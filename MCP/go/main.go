@@ -1,24 +1,48 @@
+//go:generate go run ./cmd/gen
+
 package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/auth"
 	"github.com/vault-api/mcp-server/config"
 )
 
+const (
+	serverName  = "Vault API"
+	specVersion = "10.0.0" // info.version of openapi.yaml
+)
+
 func main() {
 	cfg, err := config.LoadAPIConfig()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer func() {
+		// Flush the spans of the last tool calls.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Flushing traces failed: %v", err)
+		}
+	}()
 
 	// Check transport environment variable (both uppercase and lowercase)
 	transport := os.Getenv("TRANSPORT")
@@ -28,118 +52,314 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// HTTP/HTTPS Mode - if transport is "http", "HTTP", "https", or "HTTPS"
-	if transport == "http" || transport == "HTTP" || transport == "https" || transport == "HTTPS" {
+	// One MCP server serves every client and session. Calls pick up a
+	// reloaded configuration as soon as it is stored.
+	var current atomic.Pointer[config.APIConfig]
+	current.Store(cfg)
+	isSSE := transport == "sse" || transport == "SSE"
+	isHTTP := transport == "http" || transport == "HTTP" || transport == "https" || transport == "HTTPS" || isSSE
+	audit, err := openAuditLog(cfg.AuditLog, !isHTTP)
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+	consumers := newSessionConsumers()
+	calls := newToolCalls()
+	m := newMetrics()
+	catalog := newToolCatalog(GetAll(cfg))
+	mcpSrv := createMCPServer(&current, catalog, consumers, calls, m, audit)
+	if cfg.MetricsPort != "" {
+		go serveMetrics(cfg.MetricsPort, m)
+	}
+
+	// Inbound authentication only applies to HTTP clients.
+	var authn *auth.Authenticator
+	if isHTTP && cfg.File != nil && cfg.File.Auth.Enabled() {
+		if authn, err = auth.New(cfg.File); err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+	}
+
+	reloadOnSIGHUP(func(cfg *config.APIConfig) {
+		if authn != nil {
+			if err := authn.Update(cfg.File); err != nil {
+				log.Printf("Reloading authentication failed, keeping the current configuration: %v", err)
+				return
+			}
+		}
+		current.Store(cfg)
+		// Enabled tools and argument defaults may have changed.
+		mcpSrv.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
+	})
+
+	// HTTP/HTTPS/SSE Mode - if transport is "http", "https" or "sse", in either case
+	if isHTTP {
 		port := cfg.Port
 		if port == "" {
-			log.Fatalf("PORT environment variable is required for HTTP/HTTPS mode. Please set PORT environment variable.")
+			log.Fatalf("PORT environment variable is required for HTTP/HTTPS/SSE mode. Please set PORT environment variable.")
 		}
 
-		// Determine if HTTPS mode and normalize transport
-		isHTTPS := transport == "https" || transport == "HTTPS"
-		if isHTTPS {
+		// Determine if TLS is used and normalize transport. SSE uses TLS
+		// when a certificate is configured.
+		certFile := os.Getenv("CERT_FILE")
+		keyFile := os.Getenv("KEY_FILE")
+		isHTTPS := transport == "https" || transport == "HTTPS" || (isSSE && certFile != "" && keyFile != "")
+		switch {
+		case isSSE:
+			transport = "SSE"
+		case isHTTPS:
 			transport = "HTTPS"
-		} else {
+		default:
 			transport = "HTTP"
 		}
 		
 		log.Printf("Running in %s mode on port %s", transport, port)
 
-		mux := http.NewServeMux()
-		mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
-			// Read headers for dynamic config
-			apiCfg := &config.APIConfig{
-				BaseURL:     r.Header.Get("API_BASE_URL"),
-				BearerToken: r.Header.Get("BEARER_TOKEN"),
-				APIKey:      r.Header.Get("API_KEY"),
-				BasicAuth:   r.Header.Get("BASIC_AUTH"),
-			}
-
-			if apiCfg.BaseURL == "" {
-				http.Error(w, "Missing API_BASE_URL header", http.StatusBadRequest)
-				return
-			}
+		// protect resolves the configuration of each request before passing
+		// it on; tool handlers take it from the request context.
+		protect := func(next http.Handler) http.Handler {
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				apiCfg, err := requestConfig(current.Load(), r)
+				if errors.Is(err, config.ErrBaseURLNotAllowed) {
+					log.Printf("Rejected API_BASE_URL from %s: %v", caller(r), err)
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if errors.Is(err, errProfileNotAllowed) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
 
-			log.Printf("Incoming HTTP request - BaseURL: %s", apiCfg.BaseURL)
+				if apiCfg.BaseURL == "" {
+					http.Error(w, "Missing API_BASE_URL header (or base_url in the selected profile)", http.StatusBadRequest)
+					return
+				}
 
-			// Create MCP server for this request
-			mcpSrv := createMCPServer(apiCfg, transport)
-			handler := server.NewStreamableHTTPServer(mcpSrv, server.WithHTTPContextFunc(
-				func(ctx context.Context, req *http.Request) context.Context {
-					return context.WithValue(ctx, "apiConfig", apiCfg)
-				},
-			))
+				log.Printf("Incoming %s request - BaseURL: %s", transport, apiCfg.BaseURL)
+				r = extractTraceContext(r)
+				next.ServeHTTP(w, r.WithContext(config.NewContext(r.Context(), apiCfg)))
+			})
+			if authn != nil {
+				h = authn.Handler(h)
+			}
+			return h
+		}
+		if authn != nil {
+			log.Printf("Authenticating clients with the auth section of the config file")
+		}
 
-			handler.ServeHTTP(w, r)
-		})
+		addr := net.JoinHostPort("0.0.0.0", port)
+		mux := http.NewServeMux()
+		httpServer := &http.Server{Addr: addr, Handler: mux}
+		shutdown := httpServer.Shutdown
+		if isSSE {
+			// Clients open the event stream at /sse and post their messages
+			// to the /message endpoint it announces. Each message is handled
+			// with the configuration of its own POST request.
+			sse := server.NewSSEServer(mcpSrv, server.WithHTTPServer(httpServer))
+			mux.Handle("/sse", protect(sse.SSEHandler()))
+			mux.Handle("/message", protect(sse.MessageHandler()))
+			// Closes the open event streams, which Shutdown would wait for.
+			shutdown = sse.Shutdown
+		} else {
+			mux.Handle("/mcp", protect(server.NewStreamableHTTPServer(mcpSrv,
+				server.WithSessionIdManager(&sessionIDs{consumers: consumers}),
+			)))
+		}
 
+		mux.HandleFunc("/healthz", healthz)
+		mux.Handle("/readyz", &readiness{current: &current, calls: calls})
+		mux.HandleFunc("/version", version(&current, catalog))
+		mux.Handle("/metrics", m.handler())
 		mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 			w.Write([]byte(`{"status":"ok"}`))
 		})
 
-		addr := net.JoinHostPort("0.0.0.0", port)
-		httpServer := &http.Server{Addr: addr, Handler: mux}
-
 		go func() {
 			// Check if HTTPS mode
 			if isHTTPS {
-				certFile := os.Getenv("CERT_FILE")
-				keyFile := os.Getenv("KEY_FILE")
-				
 				if certFile == "" || keyFile == "" {
 					log.Fatalf("CERT_FILE and KEY_FILE environment variables are required for HTTPS mode")
 				}
 				
-				log.Printf("Starting HTTPS server on %s", addr)
+				log.Printf("Starting %s server with TLS on %s", transport, addr)
 				if err := httpServer.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
-					log.Fatalf("HTTPS server error: %v", err)
+					log.Fatalf("%s server error: %v", transport, err)
 				}
 			} else {
-				log.Printf("Starting HTTP server on %s", addr)
+				log.Printf("Starting %s server on %s", transport, addr)
 				if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
-					log.Fatalf("HTTP server error: %v", err)
+					log.Fatalf("%s server error: %v", transport, err)
 				}
 			}
 		}()
 
 		<-sigChan
 		log.Println("Shutdown signal received")
+		// Requests are still accepted while draining, so that running
+		// calls can deliver their results; new tool calls are refused.
+		calls.drain(current.Load().ShutdownTimeout)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		if err := httpServer.Shutdown(ctx); err != nil {
+		if err := shutdown(ctx); err != nil {
 			log.Printf("Shutdown error: %v", err)
 		} else {
-			log.Println("HTTP server shutdown complete")
+			log.Printf("%s server shutdown complete", transport)
 		}
 		return
 	}
 
 	// STDIO Mode - default when no transport or transport is "stdio"
 	log.Println("Running in STDIO mode")
-	mcp := createMCPServer(cfg, "STDIO")
+	// Tool calls run with this context, so it is only cancelled once they
+	// have been drained.
+	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
-		if err := server.ServeStdio(mcp); err != nil {
+		if err := server.NewStdioServer(mcpSrv).Listen(ctx, os.Stdin, os.Stdout); err != nil {
 			log.Fatalf("STDIO error: %v", err)
 		}
 	}()
 	<-sigChan
 	log.Println("Received shutdown signal. Exiting STDIO mode.")
+	calls.drain(current.Load().ShutdownTimeout)
+	cancel()
 }
 
-func createMCPServer(cfg *config.APIConfig, mode string) *server.MCPServer {
-	mcp := server.NewMCPServer("Vault API", "10.0.0",
+// errProfileNotAllowed is returned for an authenticated client asking for a
+// profile other than its own.
+var errProfileNotAllowed = errors.New("profile not allowed for this client")
+
+// requestConfig builds the configuration for an HTTP request from its
+// headers. When a configuration file is loaded, the profile named by the
+// PROFILE header, or else the server's own profile, supplies the values the
+// headers leave out.
+func requestConfig(cfg *config.APIConfig, r *http.Request) (*config.APIConfig, error) {
+	if id := auth.FromContext(r.Context()); id != nil {
+		return identityConfig(cfg, r, id)
+	}
+
+	apiCfg := &config.APIConfig{
+		// Deadlines and retries are server policy, not caller-supplied
+		Timeout:            cfg.Timeout,
+		ToolTimeouts:       cfg.ToolTimeouts,
+		MaxRetries:         cfg.MaxRetries,
+		RetryBaseDelay:     cfg.RetryBaseDelay,
+		RetryMaxDelay:      cfg.RetryMaxDelay,
+		RetryNonIdempotent: cfg.RetryNonIdempotent,
+		EnabledTools:       cfg.EnabledTools,
+		DisabledTools:      cfg.DisabledTools,
+		ReadOnly:           cfg.ReadOnly,
+		ConfirmDestructive: cfg.ConfirmDestructive,
+		DryRun:             cfg.DryRun,
+		BaseURLPolicy:      cfg.BaseURLPolicy,
+	}
+	if profile := r.Header.Get("PROFILE"); cfg.File != nil || profile != "" {
+		var err error
+		if apiCfg, err = cfg.WithProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+
+	// The server's credentials are only ever sent to the server's base URL.
+	// A caller pointing requests elsewhere has to send its own.
+	if r.Header.Get("API_BASE_URL") != "" {
+		apiCfg.APIKey = ""
+		apiCfg.BearerToken = ""
+		apiCfg.BasicAuth = ""
+		apiCfg.DownstreamAuthorization = ""
+	}
+
+	// Read headers for dynamic config
+	for header, field := range map[string]*string{
+		"API_BASE_URL":             &apiCfg.BaseURL,
+		"BEARER_TOKEN":             &apiCfg.BearerToken,
+		"API_KEY":                  &apiCfg.APIKey,
+		"BASIC_AUTH":               &apiCfg.BasicAuth,
+		"DOWNSTREAM_AUTHORIZATION": &apiCfg.DownstreamAuthorization,
+		"APP_ID":                   &apiCfg.AppID,
+		"CONSUMER_ID":              &apiCfg.ConsumerID,
+	} {
+		if v := r.Header.Get(header); v != "" {
+			*field = v
+		}
+	}
+
+	// A base URL from the caller makes the server send requests on its
+	// behalf, so it has to pass the policy.
+	if v := r.Header.Get("API_BASE_URL"); v != "" {
+		if err := apiCfg.BaseURLPolicy.Check(r.Context(), v); err != nil {
+			return nil, err
+		}
+		apiCfg.CallerBaseURL = true
+	}
+	return apiCfg, nil
+}
+
+// caller names the client of an HTTP request for the log: its identity when
+// it authenticated, else its address.
+func caller(r *http.Request) string {
+	if id := auth.FromContext(r.Context()); id != nil {
+		return fmt.Sprintf("%s (%s)", id.Name, r.RemoteAddr)
+	}
+	return r.RemoteAddr
+}
+
+// identityConfig builds the configuration for an authenticated client. The
+// base URL and credentials come from the identity's profile only; headers
+// may still pick a consumer, which withIdentityScope checks on every call.
+func identityConfig(cfg *config.APIConfig, r *http.Request, id *auth.Identity) (*config.APIConfig, error) {
+	profile := id.Profile
+	if profile == "" {
+		profile = cfg.Profile
+	}
+	if requested := r.Header.Get("PROFILE"); requested != "" && requested != profile {
+		return nil, fmt.Errorf("%w: %q", errProfileNotAllowed, requested)
+	}
+	apiCfg, err := cfg.WithProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if id.AppID != "" {
+		apiCfg.AppID = id.AppID
+	}
+	if v := r.Header.Get("CONSUMER_ID"); v != "" {
+		apiCfg.ConsumerID = v
+	}
+	return apiCfg, nil
+}
+
+func createMCPServer(current *atomic.Pointer[config.APIConfig], catalog toolCatalog, consumers *sessionConsumers, calls *toolCalls, m *metrics, audit *auditLog) *server.MCPServer {
+	mcp := server.NewMCPServer(serverName, specVersion,
 		server.WithToolCapabilities(true),
+		server.WithElicitation(),
 		server.WithRecovery(),
+		server.WithHooks(consumers.hooks()),
+		server.WithToolFilter(toolsFor(current, catalog, consumers)),
+		server.WithToolHandlerMiddleware(withTracing()),
+		server.WithToolHandlerMiddleware(m.middleware()),
+		server.WithToolHandlerMiddleware(calls.middleware()),
+		server.WithToolHandlerMiddleware(withConfig(current)),
+		server.WithToolHandlerMiddleware(withEnabledTools(catalog)),
+		server.WithToolHandlerMiddleware(withToolTimeout()),
+		server.WithToolHandlerMiddleware(withRetryReport()),
+		server.WithToolHandlerMiddleware(withDefaultIDs(catalog, consumers)),
+		server.WithToolHandlerMiddleware(audit.middleware(catalog)),
+		server.WithToolHandlerMiddleware(withIdentityScope(catalog)),
+		server.WithToolHandlerMiddleware(withDryRun(catalog)),
+		server.WithToolHandlerMiddleware(newConfirmations().middleware()),
 	)
 
-	tools := GetAll(cfg)
-	log.Printf("Loaded %d tools for %s mode", len(tools), mode)
+	log.Printf("Loaded %d tools", len(catalog))
 
-	for _, tool := range tools {
+	for _, tool := range catalog {
 		mcp.AddTool(tool.Definition, tool.Handler)
 	}
+	mcp.AddTools(setCurrentConsumerTool(consumers))
 
 	return mcp
-}
\ No newline at end of file
+}
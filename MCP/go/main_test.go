@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+const testConfigFile = `
+default_profile: live
+profiles:
+  live:
+    base_url: https://unify.example.com
+    api_key: sk_live_server
+    bearer_token: server-bearer
+    basic_auth: server:basic
+    downstream_authorization: Bearer server-downstream
+`
+
+func loadTestConfig(t *testing.T, env map[string]string) *config.APIConfig {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfigFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRANSPORT", "http")
+	t.Setenv("CONFIG_FILE", path)
+	for _, name := range []string{"ENV_FILE", "PROFILE", "API_BASE_URL", "API_KEY", "BEARER_TOKEN", "BASIC_AUTH", "DOWNSTREAM_AUTHORIZATION", "ALLOWED_BASE_URLS"} {
+		t.Setenv(name, "")
+	}
+	// Skip DNS lookups; the allowlist decides.
+	t.Setenv("ALLOW_PRIVATE_BASE_URLS", "true")
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+	cfg, err := config.LoadAPIConfig()
+	if err != nil {
+		t.Fatalf("LoadAPIConfig: %v", err)
+	}
+	return cfg
+}
+
+func TestRequestConfigCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		headers map[string]string
+		want    config.APIConfig // BaseURL and credentials
+		err     error
+	}{
+		{
+			name: "profile base URL keeps server credentials",
+			want: config.APIConfig{
+				BaseURL:                 "https://unify.example.com",
+				APIKey:                  "sk_live_server",
+				BearerToken:             "server-bearer",
+				BasicAuth:               "server:basic",
+				DownstreamAuthorization: "Bearer server-downstream",
+			},
+		},
+		{
+			name:    "caller base URL drops server credentials",
+			env:     map[string]string{"ALLOWED_BASE_URLS": "*"},
+			headers: map[string]string{"API_BASE_URL": "https://collector.example.net"},
+			want:    config.APIConfig{BaseURL: "https://collector.example.net"},
+		},
+		{
+			name:    "caller base URL on the configured origin drops server credentials",
+			headers: map[string]string{"API_BASE_URL": "https://unify.example.com/v2"},
+			want:    config.APIConfig{BaseURL: "https://unify.example.com/v2"},
+		},
+		{
+			name: "caller base URL with the caller's own credentials",
+			env:  map[string]string{"ALLOWED_BASE_URLS": "*"},
+			headers: map[string]string{
+				"API_BASE_URL": "https://collector.example.net",
+				"API_KEY":      "sk_caller",
+			},
+			want: config.APIConfig{BaseURL: "https://collector.example.net", APIKey: "sk_caller"},
+		},
+		{
+			name:    "caller base URL off the configured origin without an allowlist",
+			headers: map[string]string{"API_BASE_URL": "https://collector.example.net"},
+			err:     config.ErrBaseURLNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadTestConfig(t, tt.env)
+			r := httptest.NewRequest("POST", "/mcp", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+
+			got, err := requestConfig(cfg, r)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("requestConfig: err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requestConfig: %v", err)
+			}
+			if got.BaseURL != tt.want.BaseURL ||
+				got.APIKey != tt.want.APIKey ||
+				got.BearerToken != tt.want.BearerToken ||
+				got.BasicAuth != tt.want.BasicAuth ||
+				got.DownstreamAuthorization != tt.want.DownstreamAuthorization {
+				t.Errorf("requestConfig = base URL %q, API key %q, bearer %q, basic %q, downstream %q; want %q, %q, %q, %q, %q",
+					got.BaseURL, got.APIKey, got.BearerToken, got.BasicAuth, got.DownstreamAuthorization,
+					tt.want.BaseURL, tt.want.APIKey, tt.want.BearerToken, tt.want.BasicAuth, tt.want.DownstreamAuthorization)
+			}
+		})
+	}
+}
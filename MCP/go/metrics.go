@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// durationBuckets covers quick lookups as well as slow imports.
+var durationBuckets = []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// metrics are the Prometheus metrics of tool calls and of the Vault
+// requests they make.
+type metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls     *prometheus.CounterVec
+	toolErrors    *prometheus.CounterVec
+	toolDuration  *prometheus.HistogramVec
+	toolsInFlight *prometheus.GaugeVec
+
+	vaultRequests *prometheus.CounterVec
+	vaultDuration *prometheus.HistogramVec
+	vaultErrors   *prometheus.CounterVec
+	vaultRetries  *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vault_mcp_tool_calls_total",
+			Help: "Tool calls, by tool.",
+		}, []string{"tool"}),
+		toolErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vault_mcp_tool_errors_total",
+			Help: "Tool calls that returned an error result, by tool.",
+		}, []string{"tool"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vault_mcp_tool_call_duration_seconds",
+			Help:    "Duration of tool calls, by tool.",
+			Buckets: durationBuckets,
+		}, []string{"tool"}),
+		toolsInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "vault_mcp_tool_calls_in_flight",
+			Help: "Tool calls currently running, by tool.",
+		}, []string{"tool"}),
+
+		vaultRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vault_mcp_upstream_requests_total",
+			Help: `Requests sent to Vault, by method, path template and status code ("error" when no response was received).`,
+		}, []string{"method", "path", "status"}),
+		vaultDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vault_mcp_upstream_request_duration_seconds",
+			Help:    "Duration of requests sent to Vault, by method and path template.",
+			Buckets: durationBuckets,
+		}, []string{"method", "path"}),
+		vaultErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vault_mcp_upstream_errors_total",
+			Help: "Error responses from Vault, by status code and type_name.",
+		}, []string{"status_code", "type_name"}),
+		vaultRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vault_mcp_upstream_retries_total",
+			Help: "Retried requests to Vault, by method and path template.",
+		}, []string{"method", "path"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.toolCalls, m.toolErrors, m.toolDuration, m.toolsInFlight,
+		m.vaultRequests, m.vaultDuration, m.vaultErrors, m.vaultRetries,
+	)
+	return m
+}
+
+// handler serves the metrics in the Prometheus text format.
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// middleware records each tool call and observes the Vault requests it
+// makes.
+func (m *metrics) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+			m.toolCalls.WithLabelValues(tool).Inc()
+			inFlight := m.toolsInFlight.WithLabelValues(tool)
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			start := time.Now()
+			result, err := next(vault.WithObserver(ctx, m.observeRequest), request)
+			m.toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+			if err != nil || (result != nil && result.IsError) {
+				m.toolErrors.WithLabelValues(tool).Inc()
+			}
+			return result, err
+		}
+	}
+}
+
+func (m *metrics) observeRequest(info vault.RequestInfo) {
+	status := "error"
+	if info.StatusCode != 0 {
+		status = strconv.Itoa(info.StatusCode)
+	}
+	m.vaultRequests.WithLabelValues(info.Method, info.Path, status).Inc()
+	m.vaultDuration.WithLabelValues(info.Method, info.Path).Observe(info.Duration.Seconds())
+	if info.Attempt > 0 {
+		m.vaultRetries.WithLabelValues(info.Method, info.Path).Inc()
+	}
+	var apiErr *vault.APIError
+	if errors.As(info.Err, &apiErr) {
+		m.vaultErrors.WithLabelValues(status, apiErr.TypeName).Inc()
+	}
+}
+
+// serveMetrics serves /metrics on a port of its own, e.g. in STDIO mode.
+func serveMetrics(port string, m *metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.handler())
+	addr := net.JoinHostPort("0.0.0.0", port)
+	log.Printf("Serving metrics on %s/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Metrics server error: %v", err)
+	}
+}
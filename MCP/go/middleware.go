@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/auth"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// withConfig makes the configuration of each call available through its
+// context: the one resolved for the HTTP request if there is one, else the
+// server's current configuration, which SIGHUP may replace at any time.
+func withConfig(current *atomic.Pointer[config.APIConfig]) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cfg := config.FromContext(ctx, current.Load())
+			return next(config.NewContext(ctx, cfg), request)
+		}
+	}
+}
+
+// toolsFor adapts the tool list to the configuration and session of the
+// caller: tools that are not enabled are left out, arguments with a default
+// are no longer required, tools that change data offer a dry run, and tools
+// that need confirmation take a confirm token.
+func toolsFor(current *atomic.Pointer[config.APIConfig], catalog toolCatalog, consumers *sessionConsumers) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		cfg := config.FromContext(ctx, current.Load())
+		consumerID := consumers.get(ctx)
+		if consumerID == "" {
+			consumerID = cfg.ConsumerID
+		}
+		filtered := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			if !catalog.enabled(cfg, tool.Name) {
+				continue
+			}
+			tool = withDefaultArguments(tool, cfg.AppID, consumerID)
+			if generated, ok := catalog[tool.Name]; ok && !isReadOnly(generated.Definition) {
+				tool = withDryRunArgument(tool)
+			}
+			if _, ok := confirmedTools[tool.Name]; ok && cfg.ConfirmDestructive {
+				tool = withConfirmArgument(tool)
+			}
+			filtered = append(filtered, tool)
+		}
+		return filtered
+	}
+}
+
+// withEnabledTools rejects calls to tools that the caller's configuration
+// does not enable, as they are not listed for it either.
+func withEnabledTools(catalog toolCatalog) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if cfg := config.FromContext(ctx, nil); cfg != nil && !catalog.enabled(cfg, request.Params.Name) {
+				return mcp.NewToolResultError(fmt.Sprintf("Tool %s is not enabled", request.Params.Name)), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// withIdentityScope limits an authenticated client to the app ID and
+// consumer IDs of its identity. It runs after withDefaultIDs, so it checks
+// the IDs a call will actually use, including defaulted ones. Which consumer
+// a call acts for is decided by the consumer arguments in the tool's input
+// schema: a client limited to some consumers cannot call tools that take no
+// consumer, such as listing all of them, and has to name an allowed consumer
+// in every consumer argument a tool takes.
+func withIdentityScope(catalog toolCatalog) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id := auth.FromContext(ctx)
+			if id == nil {
+				return next(ctx, request)
+			}
+			name := request.Params.Name
+			args, _ := request.Params.Arguments.(map[string]any)
+
+			if appID, _ := args[appIDArgument].(string); id.AppID != "" && appID != "" && appID != id.AppID {
+				log.Printf("Denied %s for %s: app ID %q", name, id.Name, appID)
+				return mcp.NewToolResultError(fmt.Sprintf("App ID %q is not allowed", appID)), nil
+			}
+
+			if len(id.ConsumerIDs) == 0 {
+				return next(ctx, request)
+			}
+			if name == setCurrentConsumerName {
+				// An empty consumer ID clears the current consumer.
+				if consumerID, _ := args["consumer_id"].(string); consumerID != "" && !id.AllowsConsumer(consumerID) {
+					log.Printf("Denied %s for %s: consumer ID %q", name, id.Name, consumerID)
+					return mcp.NewToolResultError(fmt.Sprintf("Consumer ID %q is not allowed", consumerID)), nil
+				}
+				return next(ctx, request)
+			}
+
+			consumerArgs := consumerArguments(catalog[name].Definition)
+			if len(consumerArgs) == 0 {
+				log.Printf("Denied %s for %s: the tool takes no consumer ID", name, id.Name)
+				return mcp.NewToolResultError(fmt.Sprintf("Tool %s is not available to clients limited to consumers %s", name, strings.Join(id.ConsumerIDs, ", "))), nil
+			}
+			for _, arg := range consumerArgs {
+				consumerID, _ := args[arg].(string)
+				if consumerID == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("Tool %s needs %s matching %s", name, arg, strings.Join(id.ConsumerIDs, ", "))), nil
+				}
+				if !id.AllowsConsumer(consumerID) {
+					log.Printf("Denied %s for %s: consumer ID %q", name, id.Name, consumerID)
+					return mcp.NewToolResultError(fmt.Sprintf("Consumer ID %q is not allowed", consumerID)), nil
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// consumerArguments returns the arguments of tool that name a consumer.
+func consumerArguments(tool mcp.Tool) []string {
+	var names []string
+	for _, name := range []string{consumerIDArgument, "consumer_id"} {
+		if _, ok := tool.InputSchema.Properties[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// toolCatalog holds the generated tools by name, for the category and
+// read-only hint that decide whether a configuration enables them.
+type toolCatalog map[string]models.Tool
+
+func newToolCatalog(tools []models.Tool) toolCatalog {
+	catalog := make(toolCatalog, len(tools))
+	for _, tool := range tools {
+		catalog[tool.Definition.Name] = tool
+	}
+	return catalog
+}
+
+// enabled reports whether the named tool is available under cfg. The
+// session tools of this server are always available.
+func (c toolCatalog) enabled(cfg *config.APIConfig, name string) bool {
+	if name == setCurrentConsumerName {
+		return true
+	}
+	tool, ok := c[name]
+	if !ok {
+		return false
+	}
+	return cfg.ToolEnabled(name, tool.Category, isReadOnly(tool.Definition))
+}
+
+// withToolTimeout bounds each tool call by the deadline configured for the
+// tool. The handler's context is passed through to the Vault request, so the
+// upstream call is abandoned as soon as the deadline passes or the MCP client
+// goes away.
+func withToolTimeout() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cfg := config.FromContext(ctx, nil)
+			if cfg == nil {
+				return next(ctx, request)
+			}
+			timeout := cfg.ToolTimeout(request.Params.Name)
+			if timeout <= 0 {
+				return next(ctx, request)
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, request)
+		}
+	}
+}
+
+// withRetryReport records the Vault requests made by each tool call and, when
+// any of them had to be retried, reports the retry count in the result: as
+// "vault_retries" in _meta and as a note after the regular content.
+func withRetryReport() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, stats := vault.WithCallStats(ctx)
+			result, err := next(ctx, request)
+			if result == nil || stats.Retries() == 0 {
+				return result, err
+			}
+
+			if result.Meta == nil {
+				result.Meta = &mcp.Meta{}
+			}
+			if result.Meta.AdditionalFields == nil {
+				result.Meta.AdditionalFields = make(map[string]any)
+			}
+			result.Meta.AdditionalFields["vault_retries"] = stats.Retries()
+			result.Content = append(result.Content, mcp.NewTextContent(
+				fmt.Sprintf("Note: Vault requests were retried %d time(s) (%d requests sent)", stats.Retries(), stats.Requests()),
+			))
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/auth"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+func TestIdentityScope(t *testing.T) {
+	var requests []string
+	vaultSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/vault/consumers/test-2" {
+			w.Write([]byte(`{"status_code":200,"status":"OK","data":{"consumer_id":"test-2"}}`))
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":[]}`))
+	}))
+	defer vaultSrv.Close()
+
+	// The configured consumer is allowed, so a tool that takes no consumer
+	// must not pass just because the default was filled in.
+	cfg := &config.APIConfig{BaseURL: vaultSrv.URL, AppID: "sandbox-app", ConsumerID: "test-1", ConfirmDestructive: true}
+	var current atomic.Pointer[config.APIConfig]
+	current.Store(cfg)
+	catalog := newToolCatalog(GetAll(cfg))
+	srv := createMCPServer(&current, catalog, newSessionConsumers(), newToolCalls(), newMetrics(), nil)
+	session := newTestSession("s1")
+	id := &auth.Identity{Name: "ci", Identity: config.Identity{AppID: "sandbox-app", ConsumerIDs: []string{"test-*"}}}
+	ctx := auth.NewContext(config.NewContext(srv.WithContext(context.Background(), session), cfg), id)
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		request string // the Vault request of an allowed call
+	}{
+		{name: "list all consumers", tool: "get_vault_consumers", args: map[string]any{}},
+		{name: "list all consumers naming a consumer", tool: "get_vault_consumers", args: map[string]any{consumerIDArgument: "test-1"}},
+		{name: "defaulted consumer", tool: "get_vault_connections", args: map[string]any{}, request: "GET /vault/connections"},
+		{name: "other consumer", tool: "get_vault_connections", args: map[string]any{consumerIDArgument: "prod-1"}},
+		{name: "other app", tool: "get_vault_connections", args: map[string]any{appIDArgument: "live-app"}},
+		{name: "allowed consumer in the path", tool: "get_vault_consumers_consumer_id", args: map[string]any{"consumer_id": "test-2"}, request: "GET /vault/consumers/test-2"},
+		{name: "other consumer in the path", tool: "get_vault_consumers_consumer_id", args: map[string]any{"consumer_id": "prod-1"}},
+		{name: "tool without a consumer", tool: "get_vault_revoke_service_id_application_id", args: map[string]any{"service_id": "salesforce", "application_id": "sandbox-app"}},
+		{name: "other current consumer", tool: setCurrentConsumerName, args: map[string]any{"consumer_id": "prod-1"}},
+		{name: "allowed current consumer", tool: setCurrentConsumerName, args: map[string]any{"consumer_id": "test-2"}},
+		{name: "list all consumers with a current consumer", tool: "get_vault_consumers", args: map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			var result mcp.CallToolResult
+			handleContext(t, srv, ctx, "tools/call", map[string]any{"name": tt.tool, "arguments": tt.args}, &result)
+			allowed := tt.request != "" || tt.name == "allowed current consumer"
+			if result.IsError == allowed {
+				t.Errorf("error result = %t, want the call allowed = %t: %+v", result.IsError, allowed, result.Content)
+			}
+			if want := []string{tt.request}; tt.request != "" && !slices.Equal(requests, want) || tt.request == "" && len(requests) > 0 {
+				t.Errorf("Vault requests = %v, want %v", requests, tt.request)
+			}
+		})
+	}
+}
+
+func TestWithRetryReport(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":{"consumer_id":"c1"}}`))
+	}))
+	defer srv.Close()
+
+	client := vault.NewClient(&config.APIConfig{BaseURL: srv.URL, MaxRetries: 2, RetryBaseDelay: time.Millisecond, RetryMaxDelay: time.Millisecond})
+	handler := withRetryReport()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, err := client.ConsumersOne(ctx, vault.ConsumersOneParams{ConsumerID: "c1"}); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("handler = %v, %v", result, err)
+	}
+	if result.Meta == nil || result.Meta.AdditionalFields["vault_retries"] != 2 {
+		t.Errorf("_meta = %+v, want vault_retries 2", result.Meta)
+	}
+	if len(result.Content) != 2 {
+		t.Errorf("content = %+v, want the result and a retry note", result.Content)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+// reloadOnSIGHUP reloads the configuration each time the process receives
+// SIGHUP, so that rotated secret files and edited env-files or config files
+// take effect without a restart. apply receives the new configuration; if it
+// fails to load, the error is logged and the current configuration stays in
+// effect.
+func reloadOnSIGHUP(apply func(*config.APIConfig)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			cfg, err := config.LoadAPIConfig()
+			if err != nil {
+				log.Printf("Reloading config failed, keeping the current one: %v", err)
+				continue
+			}
+			apply(cfg)
+			log.Println("Configuration reloaded")
+		}
+	}()
+}
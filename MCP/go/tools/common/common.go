@@ -0,0 +1,118 @@
+// Package common holds the helpers shared by the tool handlers in tools/*.
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+// Client returns a Vault client for the configuration of the current call:
+// the one carried by ctx, which in HTTP mode is resolved per request, or cfg
+// when ctx carries none.
+func Client(ctx context.Context, cfg *config.APIConfig) *vault.Client {
+	return vault.NewClient(config.FromContext(ctx, cfg))
+}
+
+// BindArguments decodes the tool call arguments into dst, matching argument
+// names against the JSON tags of dst.
+func BindArguments(args map[string]any, dst any) error {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(argsJSON, dst)
+}
+
+// BindBody decodes the request body arguments into dst. bodyArguments maps
+// the name of each tool argument that belongs to the body to the name of the
+// body field; all other arguments (path, query and header parameters) are
+// left out of the body.
+func BindBody(args map[string]any, bodyArguments map[string]string, dst any) error {
+	body := make(map[string]any, len(bodyArguments))
+	for arg, field := range bodyArguments {
+		if val, ok := args[arg]; ok {
+			body[field] = val
+		}
+	}
+	return BindArguments(body, dst)
+}
+
+// Result converts the outcome of a vault.Client call into a tool result.
+func Result(result any, err error) (*mcp.CallToolResult, error) {
+	if err != nil {
+		var decodeErr *vault.DecodeError
+		if errors.As(err, &decodeErr) {
+			// Fallback to raw text if unmarshaling fails
+			return mcp.NewToolResultText(string(decodeErr.Body)), nil
+		}
+		return ErrorResult(err), nil
+	}
+
+	prettyJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(prettyJSON)), nil
+}
+
+// NoContentResult converts the outcome of a vault.Client call without a
+// response body into a tool result.
+func NoContentResult(err error) (*mcp.CallToolResult, error) {
+	if err != nil {
+		return ErrorResult(err), nil
+	}
+	return mcp.NewToolResultText("No content"), nil
+}
+
+// ErrorResult converts an error returned by vault.Client into a tool error.
+// Cancelled and timed out calls are reported separately from failed ones.
+func ErrorResult(err error) *mcp.CallToolResult {
+	var apiErr *vault.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErrorResult(apiErr)
+	case errors.Is(err, context.DeadlineExceeded):
+		return mcp.NewToolResultError("Request timed out: the tool call deadline passed before Vault responded")
+	case errors.Is(err, context.Canceled):
+		return mcp.NewToolResultError("Request cancelled: the tool call was cancelled before Vault responded")
+	}
+	return mcp.NewToolResultErrorFromErr("Request failed", err)
+}
+
+// apiErrorResult reports a Vault error response both as structured content
+// (status_code, type_name, message, detail, ref) and as readable text, so
+// that an auth failure can be told apart from a validation failure.
+func apiErrorResult(apiErr *vault.APIError) *mcp.CallToolResult {
+	var text strings.Builder
+	fmt.Fprintf(&text, "API error %d", apiErr.StatusCode)
+	if apiErr.TypeName != "" {
+		fmt.Fprintf(&text, " %s", apiErr.TypeName)
+	}
+	if apiErr.Message != "" {
+		fmt.Fprintf(&text, ": %s", apiErr.Message)
+	} else if len(apiErr.Body) > 0 {
+		fmt.Fprintf(&text, ": %s", apiErr.Body)
+	}
+	if apiErr.Detail != nil {
+		detail, ok := apiErr.Detail.(string)
+		if !ok {
+			detailJSON, _ := json.Marshal(apiErr.Detail)
+			detail = string(detailJSON)
+		}
+		fmt.Fprintf(&text, "\nDetail: %s", detail)
+	}
+	if apiErr.Ref != "" {
+		fmt.Fprintf(&text, "\nRef: %s", apiErr.Ref)
+	}
+
+	result := mcp.NewToolResultStructured(apiErr, text.String())
+	result.IsError = true
+	return result
+}
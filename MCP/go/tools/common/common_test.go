@@ -0,0 +1,87 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/vault"
+)
+
+func TestErrorResultOfAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		structured map[string]any
+		text       string
+	}{
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"status_code":401,"error":"Unauthorized","type_name":"UnauthorizedError","message":"Unauthorized Request","detail":"Missing authentication","ref":"https://developers.apideck.com/errors#unauthorizederror"}`,
+			structured: map[string]any{
+				"status_code": 401.0,
+				"type_name":   "UnauthorizedError",
+				"message":     "Unauthorized Request",
+				"detail":      "Missing authentication",
+				"ref":         "https://developers.apideck.com/errors#unauthorizederror",
+			},
+			text: "API error 401 UnauthorizedError: Unauthorized Request\nDetail: Missing authentication\nRef: https://developers.apideck.com/errors#unauthorizederror",
+		},
+		{
+			name:   "validation with an object detail",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"status_code":422,"error":"Unprocessable Entity","type_name":"ValidationError","message":"Invalid settings","detail":{"missing":[{"instance_url":"required"}]},"ref":"https://developers.apideck.com/errors#validationerror"}`,
+			structured: map[string]any{
+				"status_code": 422.0,
+				"type_name":   "ValidationError",
+				"message":     "Invalid settings",
+				"detail":      map[string]any{"missing": []any{map[string]any{"instance_url": "required"}}},
+				"ref":         "https://developers.apideck.com/errors#validationerror",
+			},
+			text: "API error 422 ValidationError: Invalid settings\nDetail: {\"missing\":[{\"instance_url\":\"required\"}]}\nRef: https://developers.apideck.com/errors#validationerror",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			result, err := Result(vault.NewClient(&config.APIConfig{BaseURL: srv.URL}).ConsumersOne(context.Background(), vault.ConsumersOneParams{ConsumerID: "c1"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.IsError {
+				t.Error("result is not an error")
+			}
+
+			encoded, err := json.Marshal(result.StructuredContent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var structured map[string]any
+			if err := json.Unmarshal(encoded, &structured); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(structured, tt.structured) {
+				t.Errorf("structured content = %v, want %v", structured, tt.structured)
+			}
+
+			if len(result.Content) != 1 {
+				t.Fatalf("content = %+v, want one text", result.Content)
+			}
+			if text, _ := result.Content[0].(mcp.TextContent); text.Text != tt.text {
+				t.Errorf("text = %q, want %q", text.Text, tt.text)
+			}
+		})
+	}
+}
@@ -1,16 +1,16 @@
+// Code generated by cmd/gen from openapi.yaml. DO NOT EDIT.
+
 package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/vault-api/mcp-server/config"
 	"github.com/vault-api/mcp-server/models"
-	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/tools/common"
+	"github.com/vault-api/mcp-server/vault"
 )
 
 func ConnectionsallHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -19,86 +19,22 @@ func ConnectionsallHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
-		queryParams := make([]string, 0)
-		if val, ok := args["api"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("api=%v", val))
-		}
-		if val, ok := args["configured"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("configured=%v", val))
-		}
-		// Handle multiple authentication parameters
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("code=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("redirect_uri=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("scope=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("state=%s", cfg.APIKey))
-		}
-		if cfg.BearerToken != "" {
-			queryParams = append(queryParams, fmt.Sprintf("x-apideck-downstream-authorization=%s", cfg.BearerToken))
-		}
-		queryString := ""
-		if len(queryParams) > 0 {
-			queryString = "?" + strings.Join(queryParams, "&")
-		}
-		url := fmt.Sprintf("%s/vault/connections%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
-		}
-		// Set authentication based on auth type
-		// Handle multiple authentication parameters
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		req.Header.Set("Accept", "application/json")
-		if val, ok := args["x-apideck-consumer-id"]; ok {
-			req.Header.Set("x-apideck-consumer-id", fmt.Sprintf("%v", val))
+		var params vault.ConnectionsAllParams
+		if err := common.BindArguments(args, &params); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to parameters: %v", err)), nil
 		}
-		if val, ok := args["x-apideck-app-id"]; ok {
-			req.Header.Set("x-apideck-app-id", fmt.Sprintf("%v", val))
-		}
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
-		}
-
-		if resp.StatusCode >= 400 {
-			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
-		}
-		// Use properly typed response
-		var result models.GetConnectionsResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
-		}
-
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return common.Result(common.Client(ctx, cfg).ConnectionsAll(ctx, params))
 	}
 }
 
 func CreateConnectionsallTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_vault_connections",
 		mcp.WithDescription("Get all connections"),
+		mcp.WithTitleAnnotation("Get all connections"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
 		mcp.WithString("x-apideck-consumer-id", mcp.Required(), mcp.Description("ID of the consumer which you want to get or push data from")),
 		mcp.WithString("x-apideck-app-id", mcp.Required(), mcp.Description("The ID of your Unify application")),
 		mcp.WithString("api", mcp.Description("Scope results to Unified API")),
@@ -108,5 +44,6 @@ func CreateConnectionsallTool(cfg *config.APIConfig) models.Tool {
 	return models.Tool{
 		Definition: tool,
 		Handler:    ConnectionsallHandler(cfg),
+		Category:   "connections",
 	}
 }
@@ -1,16 +1,16 @@
+// Code generated by cmd/gen from openapi.yaml. DO NOT EDIT.
+
 package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/vault-api/mcp-server/config"
 	"github.com/vault-api/mcp-server/models"
-	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/tools/common"
+	"github.com/vault-api/mcp-server/vault"
 )
 
 func ConnectionsauthorizeHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -19,99 +19,22 @@ func ConnectionsauthorizeHandler(cfg *config.APIConfig) func(ctx context.Context
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
-		service_idVal, ok := args["service_id"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: service_id"), nil
-		}
-		service_id, ok := service_idVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: service_id"), nil
-		}
-		application_idVal, ok := args["application_id"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: application_id"), nil
-		}
-		application_id, ok := application_idVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: application_id"), nil
-		}
-		queryParams := make([]string, 0)
-		if val, ok := args["state"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("state=%v", val))
-		}
-		if val, ok := args["redirect_uri"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("redirect_uri=%v", val))
-		}
-		if val, ok := args["scope"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("scope=%v", val))
-		}
-		// Handle multiple authentication parameters
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("code=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("redirect_uri=%s", cfg.APIKey))
+		var params vault.ConnectionsAuthorizeParams
+		if err := common.BindArguments(args, &params); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to parameters: %v", err)), nil
 		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("scope=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("state=%s", cfg.APIKey))
-		}
-		if cfg.BearerToken != "" {
-			queryParams = append(queryParams, fmt.Sprintf("x-apideck-downstream-authorization=%s", cfg.BearerToken))
-		}
-		queryString := ""
-		if len(queryParams) > 0 {
-			queryString = "?" + strings.Join(queryParams, "&")
-		}
-		url := fmt.Sprintf("%s/vault/authorize/%s/%s%s", cfg.BaseURL, service_id, application_id, queryString)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
-		}
-		// Set authentication based on auth type
-		// Handle multiple authentication parameters
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
-		}
-
-		if resp.StatusCode >= 400 {
-			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
-		}
-		// Use properly typed response
-		var result models.UnexpectedErrorResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
-		}
-
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return common.Result(common.Client(ctx, cfg).ConnectionsAuthorize(ctx, params))
 	}
 }
 
 func CreateConnectionsauthorizeTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_vault_authorize_service_id_application_id",
 		mcp.WithDescription("Authorize"),
+		mcp.WithTitleAnnotation("Get connection authorize link"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithString("service_id", mcp.Required(), mcp.Description("Service ID of the resource to return")),
 		mcp.WithString("application_id", mcp.Required(), mcp.Description("Application ID of the resource to return")),
 		mcp.WithString("state", mcp.Required(), mcp.Description("An opaque value the applications adds to the initial request that the authorization server includes when redirecting the back to the application. This value must be used by the application to prevent CSRF attacks.")),
@@ -122,5 +45,6 @@ func CreateConnectionsauthorizeTool(cfg *config.APIConfig) models.Tool {
 	return models.Tool{
 		Definition: tool,
 		Handler:    ConnectionsauthorizeHandler(cfg),
+		Category:   "connections",
 	}
 }
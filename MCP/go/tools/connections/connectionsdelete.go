@@ -1,16 +1,16 @@
+// Code generated by cmd/gen from openapi.yaml. DO NOT EDIT.
+
 package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/vault-api/mcp-server/config"
 	"github.com/vault-api/mcp-server/models"
-	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vault-api/mcp-server/tools/common"
+	"github.com/vault-api/mcp-server/vault"
 )
 
 func ConnectionsdeleteHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -19,96 +19,22 @@ func ConnectionsdeleteHandler(cfg *config.APIConfig) func(ctx context.Context, r
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
-		service_idVal, ok := args["service_id"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: service_id"), nil
-		}
-		service_id, ok := service_idVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: service_id"), nil
-		}
-		unified_apiVal, ok := args["unified_api"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: unified_api"), nil
-		}
-		unified_api, ok := unified_apiVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: unified_api"), nil
-		}
-		queryParams := make([]string, 0)
-		// Handle multiple authentication parameters
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("code=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("redirect_uri=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("scope=%s", cfg.APIKey))
-		}
-		if cfg.APIKey != "" {
-			queryParams = append(queryParams, fmt.Sprintf("state=%s", cfg.APIKey))
-		}
-		if cfg.BearerToken != "" {
-			queryParams = append(queryParams, fmt.Sprintf("x-apideck-downstream-authorization=%s", cfg.BearerToken))
+		var params vault.ConnectionsDeleteParams
+		if err := common.BindArguments(args, &params); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to parameters: %v", err)), nil
 		}
-		queryString := ""
-		if len(queryParams) > 0 {
-			queryString = "?" + strings.Join(queryParams, "&")
-		}
-		url := fmt.Sprintf("%s/vault/connections/%s/%s%s", cfg.BaseURL, service_id, unified_api, queryString)
-		req, err := http.NewRequest("DELETE", url, nil)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
-		}
-		// Set authentication based on auth type
-		// Handle multiple authentication parameters
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		// API key already added to query string
-		req.Header.Set("Accept", "application/json")
-		if val, ok := args["x-apideck-consumer-id"]; ok {
-			req.Header.Set("x-apideck-consumer-id", fmt.Sprintf("%v", val))
-		}
-		if val, ok := args["x-apideck-app-id"]; ok {
-			req.Header.Set("x-apideck-app-id", fmt.Sprintf("%v", val))
-		}
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
-		}
-
-		if resp.StatusCode >= 400 {
-			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
-		}
-		// Use properly typed response
-		var result models.UnexpectedErrorResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
-		}
-
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return common.NoContentResult(common.Client(ctx, cfg).ConnectionsDelete(ctx, params))
 	}
 }
 
 func CreateConnectionsdeleteTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("delete_vault_connections_unified_api_service_id",
 		mcp.WithDescription("Deletes a connection"),
+		mcp.WithTitleAnnotation("Deletes a connection"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
 		mcp.WithString("x-apideck-consumer-id", mcp.Required(), mcp.Description("ID of the consumer which you want to get or push data from")),
 		mcp.WithString("x-apideck-app-id", mcp.Required(), mcp.Description("The ID of your Unify application")),
 		mcp.WithString("service_id", mcp.Required(), mcp.Description("Service ID of the resource to return")),
@@ -118,5 +44,6 @@ func CreateConnectionsdeleteTool(cfg *config.APIConfig) models.Tool {
 	return models.Tool{
 		Definition: tool,
 		Handler:    ConnectionsdeleteHandler(cfg),
+		Category:   "connections",
 	}
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vault-api/mcp-server/vault"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/vault-api/mcp-server")
+
+// setupTracing installs the trace exporter selected by OTEL_TRACES_EXPORTER:
+//   - "otlp" sends spans over OTLP/HTTP, configured through the standard
+//     OTEL_EXPORTER_OTLP_* variables such as OTEL_EXPORTER_OTLP_ENDPOINT
+//   - "console" writes spans as JSON to stderr (stdout carries the STDIO
+//     transport)
+//   - "file" writes spans as JSON lines to the file named by OTEL_TRACES_FILE
+//
+// Tracing is off when it is unset or "none". The returned function flushes
+// and stops the exporter.
+func setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch name := os.Getenv("OTEL_TRACES_EXPORTER"); name {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "console":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case "file":
+		exporter, err = fileExporter(os.Getenv("OTEL_TRACES_FILE"))
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q (want otlp, console, file or none)", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "vault-mcp-server"
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", specVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
+
+func fileExporter(path string) (sdktrace.SpanExporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("OTEL_TRACES_FILE is required for the file exporter")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &closingExporter{SpanExporter: exporter, closer: f}, nil
+}
+
+// closingExporter closes the exporter's file once it has been shut down.
+type closingExporter struct {
+	sdktrace.SpanExporter
+	closer io.Closer
+}
+
+func (e *closingExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.closer.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// extractTraceContext continues the trace of the client, as sent in the
+// traceparent and baggage headers of an HTTP request.
+func extractTraceContext(r *http.Request) *http.Request {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return r.WithContext(ctx)
+}
+
+// withTracing records a span for each tool call. The Vault requests the call
+// makes become its children.
+func withTracing() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			attrs := []attribute.KeyValue{attribute.String("mcp.tool.name", request.Params.Name)}
+			args, _ := request.Params.Arguments.(map[string]any)
+			for _, name := range []string{"unified_api", "service_id"} {
+				if v, ok := args[name].(string); ok && v != "" {
+					attrs = append(attrs, attribute.String("vault."+name, v))
+				}
+			}
+			ctx, span := tracer.Start(ctx, "tools/call "+request.Params.Name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+			ctx = vault.WithObserver(ctx, func(info vault.RequestInfo) {
+				// The status code of the last Vault response decided the outcome.
+				if info.StatusCode != 0 {
+					span.SetAttributes(attribute.Int("vault.status_code", info.StatusCode))
+				}
+			})
+
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			case result != nil && result.IsError:
+				span.SetStatus(codes.Error, "tool returned an error result")
+			}
+			return result, err
+		}
+	}
+}
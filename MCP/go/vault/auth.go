@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// Header names of the securitySchemes declared in openapi.yaml.
+const (
+	authorizationHeader           = "Authorization"
+	appIDHeader                   = "x-apideck-app-id"
+	downstreamAuthorizationHeader = "x-apideck-downstream-authorization"
+)
+
+// authenticate applies the Vault securitySchemes to req:
+//
+//   - apiKey: "Authorization: Bearer <api-key>", taken from APIKey, or from
+//     BearerToken when no API key is set. BasicAuth is sent as
+//     "Authorization: Basic ..." when neither is configured.
+//   - applicationId: the "x-apideck-app-id" header.
+//   - the optional "x-apideck-downstream-authorization" header, which makes
+//     Vault skip its own token injection.
+//
+// Operations declared with an empty security requirement (authorize,
+// callback and revoke) are sent without credentials.
+func (c *Client) authenticate(req *http.Request, r request) {
+	if r.public {
+		return
+	}
+
+	switch {
+	case c.cfg.APIKey != "":
+		req.Header.Set(authorizationHeader, "Bearer "+c.cfg.APIKey)
+	case c.cfg.BearerToken != "":
+		req.Header.Set(authorizationHeader, "Bearer "+c.cfg.BearerToken)
+	case c.cfg.BasicAuth != "":
+		req.Header.Set(authorizationHeader, basicAuthorization(c.cfg.BasicAuth))
+	}
+
+	if r.appID != "" {
+		req.Header.Set(appIDHeader, r.appID)
+	}
+	if c.cfg.DownstreamAuthorization != "" {
+		req.Header.Set(downstreamAuthorizationHeader, c.cfg.DownstreamAuthorization)
+	}
+}
+
+// basicAuthorization returns the Authorization header value for credentials
+// given either as "user:password" or already base64-encoded.
+func basicAuthorization(credentials string) string {
+	if strings.Contains(credentials, ":") {
+		credentials = base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+	return "Basic " + credentials
+}
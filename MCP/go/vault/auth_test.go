@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+// recordingServer answers every request with an empty list, or a redirect
+// for the OAuth flow, and records the last request in last.
+func recordingServer(t *testing.T, last **http.Request) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r
+		if r.URL.Path == "/vault/callback" || strings.HasPrefix(r.URL.Path, "/vault/authorize/") || strings.HasPrefix(r.URL.Path, "/vault/revoke/") {
+			w.Header().Set("Location", "https://example.com/done")
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.APIConfig
+		authorization string
+	}{
+		{name: "API key", cfg: config.APIConfig{APIKey: "sk_live_key", BearerToken: "bearer-token", BasicAuth: "user:pass"}, authorization: "Bearer sk_live_key"},
+		{name: "bearer token", cfg: config.APIConfig{BearerToken: "bearer-token", BasicAuth: "user:pass"}, authorization: "Bearer bearer-token"},
+		{name: "basic user and password", cfg: config.APIConfig{BasicAuth: "user:pass"}, authorization: "Basic dXNlcjpwYXNz"},
+		{name: "basic already encoded", cfg: config.APIConfig{BasicAuth: "dXNlcjpwYXNz"}, authorization: "Basic dXNlcjpwYXNz"},
+		{name: "no credentials"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var last *http.Request
+			cfg := tt.cfg
+			cfg.BaseURL = recordingServer(t, &last).URL
+			cfg.DownstreamAuthorization = "Bearer downstream"
+			if _, err := NewClient(&cfg).ConsumersAll(context.Background(), ConsumersAllParams{AppID: "sandbox-app"}); err != nil {
+				t.Fatalf("ConsumersAll: %v", err)
+			}
+
+			if got := last.Header.Get(authorizationHeader); got != tt.authorization {
+				t.Errorf("Authorization = %q, want %q", got, tt.authorization)
+			}
+			if got := last.Header.Get(appIDHeader); got != "sandbox-app" {
+				t.Errorf("%s = %q, want sandbox-app", appIDHeader, got)
+			}
+			if got := last.Header.Get(downstreamAuthorizationHeader); got != "Bearer downstream" {
+				t.Errorf("%s = %q, want Bearer downstream", downstreamAuthorizationHeader, got)
+			}
+			for _, secret := range []string{"sk_live_key", "bearer-token", "user", "pass", "dXNlcjpwYXNz", "downstream"} {
+				if strings.Contains(last.URL.RawQuery, secret) {
+					t.Errorf("query %q contains a credential", last.URL.RawQuery)
+				}
+			}
+		})
+	}
+}
+
+func TestPublicOperationsSendNoCredentials(t *testing.T) {
+	var last *http.Request
+	client := NewClient(&config.APIConfig{
+		BaseURL:                 recordingServer(t, &last).URL,
+		APIKey:                  "sk_live_key",
+		AppID:                   "sandbox-app",
+		DownstreamAuthorization: "Bearer downstream",
+	})
+	ctx := context.Background()
+
+	calls := map[string]func() (*Redirect, error){
+		"authorize": func() (*Redirect, error) {
+			return client.ConnectionsAuthorize(ctx, ConnectionsAuthorizeParams{ServiceID: "salesforce", ApplicationID: "sandbox-app", State: "s", RedirectURI: "https://example.com/done"})
+		},
+		"callback": func() (*Redirect, error) {
+			return client.ConnectionsCallback(ctx, ConnectionsCallbackParams{State: "s", Code: "c"})
+		},
+		"revoke": func() (*Redirect, error) {
+			return client.ConnectionsRevoke(ctx, ConnectionsRevokeParams{ServiceID: "salesforce", ApplicationID: "sandbox-app", State: "s", RedirectURI: "https://example.com/done"})
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			last = nil
+			if _, err := call(); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			for _, header := range []string{authorizationHeader, appIDHeader, downstreamAuthorizationHeader} {
+				if got := last.Header.Get(header); got != "" {
+					t.Errorf("%s sent %s: %q", name, header, got)
+				}
+			}
+			if strings.Contains(last.URL.RawQuery, "sk_live_key") || strings.Contains(last.URL.RawQuery, "downstream") {
+				t.Errorf("query %q contains a credential", last.URL.RawQuery)
+			}
+		})
+	}
+}
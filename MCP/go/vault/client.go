@@ -0,0 +1,224 @@
+// Package vault is a typed client for the Apideck Vault API.
+//
+// It is used by the MCP tool handlers in tools/*, and can be imported
+// directly by Go services that want to call Vault without going through MCP.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+// Client performs requests against the Vault API using the base URL and
+// credentials from an APIConfig.
+type Client struct {
+	cfg        *config.APIConfig
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for outbound requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a Client for the given configuration.
+func NewClient(cfg *config.APIConfig, opts ...Option) *Client {
+	c := &Client{
+		cfg: cfg,
+		httpClient: &http.Client{
+			// Authorize, callback and revoke answer with a redirect that the
+			// caller has to follow in a browser, so never follow it here.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+	if cfg.CallerBaseURL && !cfg.BaseURLPolicy.AllowPrivate {
+		c.httpClient.Transport = publicTransport
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// publicTransport only connects to public addresses. It is used for base URLs
+// supplied by callers, whose host may resolve differently by the time of the
+// request than when it was checked. Proxies are not used, as they would hide
+// the address actually connected to.
+var publicTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if addr, err := netip.ParseAddr(host); err != nil || !config.PublicAddr(addr) {
+				return fmt.Errorf("%w: connecting to %s", config.ErrBaseURLNotAllowed, address)
+			}
+			return nil
+		},
+	}
+	t.DialContext = dialer.DialContext
+	return t
+}()
+
+// Redirect is the result of operations that answer with a 3xx redirect.
+type Redirect struct {
+	StatusCode int    `json:"status_code"`
+	Location   string `json:"location"`
+}
+
+// request describes a single Vault API call.
+type request struct {
+	method     string
+	path       string // path template, e.g. /vault/consumers/{consumer_id}
+	pathParams map[string]string
+	query      []queryParam
+	header     map[string]string
+	appID      string // sent as the applicationId security scheme
+	public     bool   // the operation declares no security requirement
+	body       any
+}
+
+// do sends r and decodes a successful JSON response into out. out may be nil
+// for operations without a response body, or a *Redirect for operations that
+// answer with a redirect. Failed attempts are retried according to the
+// client's retry policy. In a dry run, r is only prepared and recorded.
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	if dry := dryRunFromContext(ctx); dry != nil {
+		req, err := c.newRequest(ctx, r)
+		if err != nil {
+			return err
+		}
+		if err := dry.record(req); err != nil {
+			return err
+		}
+		return ErrDryRun
+	}
+
+	stats := callStatsFromContext(ctx)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			stats.addRetry()
+		}
+		stats.addRequest()
+
+		spanCtx, span := startSpan(ctx, r, attempt)
+		req, err := c.newRequest(spanCtx, r)
+		if err != nil {
+			span.End()
+			return err
+		}
+		injectTraceContext(spanCtx, req)
+		start := time.Now()
+		resp, body, err := c.send(req)
+		observe(ctx, r, attempt, start, resp, body, err)
+		endSpan(span, resp, body, err)
+		wait, retry := c.retryDelay(ctx, r, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return err
+			}
+			return decodeResponse(resp, body, out)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return fmt.Errorf("vault: %s %s: waiting to retry: %w", r.method, r.path, err)
+		}
+	}
+}
+
+// send performs a single attempt and reads the whole response body.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vault: %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vault: reading response body: %w", err)
+	}
+	return resp, body, nil
+}
+
+func decodeResponse(resp *http.Response, body []byte, out any) error {
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp.StatusCode, body)
+	}
+
+	if redirect, ok := out.(*Redirect); ok {
+		redirect.StatusCode = resp.StatusCode
+		redirect.Location = resp.Header.Get("Location")
+		return nil
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return &DecodeError{StatusCode: resp.StatusCode, Body: body, Err: err}
+	}
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, r request) (*http.Request, error) {
+	path, err := expandPath(r.path, r.pathParams)
+	if err != nil {
+		return nil, err
+	}
+	query, err := encodeQuery(r.query)
+	if err != nil {
+		return nil, err
+	}
+
+	u := strings.TrimRight(c.cfg.BaseURL, "/") + path
+	if query != "" {
+		u += "?" + query
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		bodyBytes, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("vault: encoding request body: %w", err)
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("vault: creating request: %w", err)
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range r.header {
+		if value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	c.authenticate(req, r)
+	return req, nil
+}
@@ -0,0 +1,117 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrDryRun is returned by Client methods called with a context from
+// WithDryRun: the request was prepared but not sent.
+var ErrDryRun = errors.New("vault: dry run, request not sent")
+
+// redacted replaces secret values in prepared requests.
+const redacted = "[REDACTED]"
+
+// SecretName matches the names of query parameters, tool arguments and
+// fields whose values are secret: credentials, the OAuth code and the state
+// JWT. Their values are not shown in prepared requests or the audit log.
+var SecretName = regexp.MustCompile(`(?i)secret|token|password|passwd|api_?key|authorization|private_?key|code|state`)
+
+// PreparedRequest is a request a Client prepared in a dry run, with the
+// credentials it would have sent redacted.
+type PreparedRequest struct {
+	Method string            `json:"method"`
+	URL    string            `json:"url"`
+	Header map[string]string `json:"headers"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+}
+
+// DryRun collects the requests prepared with a context from WithDryRun.
+type DryRun struct {
+	mu       sync.Mutex
+	requests []PreparedRequest
+}
+
+// Requests returns the requests prepared so far.
+func (d *DryRun) Requests() []PreparedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]PreparedRequest(nil), d.requests...)
+}
+
+func (d *DryRun) record(req *http.Request) error {
+	prepared := PreparedRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+		Header: make(map[string]string, len(req.Header)),
+	}
+	for name := range req.Header {
+		prepared.Header[name] = req.Header.Get(name)
+	}
+	if v := prepared.Header[authorizationHeader]; v != "" {
+		scheme, _, _ := strings.Cut(v, " ")
+		prepared.Header[authorizationHeader] = scheme + " " + redacted
+	}
+	if _, ok := prepared.Header[http.CanonicalHeaderKey(downstreamAuthorizationHeader)]; ok {
+		prepared.Header[http.CanonicalHeaderKey(downstreamAuthorizationHeader)] = redacted
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		prepared.Body = body
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, prepared)
+	return nil
+}
+
+// redactURL hides the password and secret query values of u. Only the
+// secret values are replaced in the raw query, so the URL otherwise reads as
+// it would be sent.
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		key, _, hasValue := strings.Cut(param, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if hasValue && SecretName.MatchString(name) {
+			params[i] = key + "=" + redacted
+		}
+	}
+	redactedURL.RawQuery = strings.Join(params, "&")
+	return redactedURL.Redacted()
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which Client methods prepare their request
+// and return ErrDryRun instead of sending it. The prepared requests are
+// collected in the returned DryRun.
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	dry := &DryRun{}
+	return context.WithValue(ctx, dryRunKey{}, dry), dry
+}
+
+// IsDryRun reports whether ctx comes from WithDryRun.
+func IsDryRun(ctx context.Context) bool {
+	return dryRunFromContext(ctx) != nil
+}
+
+func dryRunFromContext(ctx context.Context) *DryRun {
+	dry, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return dry
+}
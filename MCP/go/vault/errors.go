@@ -0,0 +1,100 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vault-api/mcp-server/models"
+)
+
+// APIError is returned when Vault answers with a status code of 400 or higher.
+//
+// Response holds the body decoded into the error type the spec declares for
+// the status code: *models.BadRequestResponse, *models.UnauthorizedResponse,
+// *models.PaymentRequiredResponse, *models.NotFoundResponse,
+// *models.UnprocessableResponse, *models.NotImplementedResponse, or
+// *models.UnexpectedErrorResponse for any other status. It is nil when the
+// body does not match that type; Body always holds the raw response.
+type APIError struct {
+	StatusCode int    `json:"status_code"`
+	TypeName   string `json:"type_name,omitempty"` // e.g. UnauthorizedError, ValidationError
+	Message    string `json:"message,omitempty"`
+	Detail     any    `json:"detail,omitempty"`
+	Ref        string `json:"ref,omitempty"` // Link to documentation of the error type
+
+	Response any    `json:"-"`
+	Body     []byte `json:"-"`
+}
+
+// newAPIError decodes an error response body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Body: body}
+
+	// Decode the fields shared by all error schemas leniently: detail is a
+	// string in some schemas and an object in others.
+	var fields struct {
+		TypeName string `json:"type_name"`
+		Message  string `json:"message"`
+		Detail   any    `json:"detail"`
+		Ref      string `json:"ref"`
+	}
+	if err := json.Unmarshal(body, &fields); err == nil {
+		e.TypeName = fields.TypeName
+		e.Message = fields.Message
+		e.Detail = fields.Detail
+		e.Ref = fields.Ref
+	}
+
+	response := errorResponse(statusCode)
+	if err := json.Unmarshal(body, response); err == nil {
+		e.Response = response
+	}
+	return e
+}
+
+// errorResponse returns a pointer to the error type declared for statusCode.
+func errorResponse(statusCode int) any {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return &models.BadRequestResponse{}
+	case http.StatusUnauthorized:
+		return &models.UnauthorizedResponse{}
+	case http.StatusPaymentRequired:
+		return &models.PaymentRequiredResponse{}
+	case http.StatusNotFound:
+		return &models.NotFoundResponse{}
+	case http.StatusUnprocessableEntity:
+		return &models.UnprocessableResponse{}
+	case http.StatusNotImplemented:
+		return &models.NotImplementedResponse{}
+	default:
+		return &models.UnexpectedErrorResponse{}
+	}
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("vault: API error (status %d): %s", e.StatusCode, e.Body)
+	}
+	if e.TypeName == "" {
+		return fmt.Sprintf("vault: API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("vault: %s (status %d): %s", e.TypeName, e.StatusCode, e.Message)
+}
+
+// DecodeError is returned when a successful response body cannot be decoded
+// into the operation's response type. Body holds the raw response.
+type DecodeError struct {
+	StatusCode int
+	Body       []byte
+	Err        error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("vault: decoding response (status %d): %v", e.StatusCode, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
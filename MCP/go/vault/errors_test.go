@@ -0,0 +1,81 @@
+package vault
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vault-api/mcp-server/models"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		want     APIError
+		response any
+		text     string
+	}{
+		{
+			name:   "unauthorized",
+			status: 401,
+			body:   `{"status_code":401,"error":"Unauthorized","type_name":"UnauthorizedError","message":"Unauthorized Request","detail":"Missing authentication","ref":"https://developers.apideck.com/errors#unauthorizederror"}`,
+			want: APIError{
+				StatusCode: 401,
+				TypeName:   "UnauthorizedError",
+				Message:    "Unauthorized Request",
+				Detail:     "Missing authentication",
+				Ref:        "https://developers.apideck.com/errors#unauthorizederror",
+			},
+			response: &models.UnauthorizedResponse{
+				Detail:      "Missing authentication",
+				ErrorField:  "Unauthorized",
+				Message:     "Unauthorized Request",
+				Ref:         "https://developers.apideck.com/errors#unauthorizederror",
+				Status_code: 401,
+				Type_name:   "UnauthorizedError",
+			},
+			text: "vault: UnauthorizedError (status 401): Unauthorized Request",
+		},
+		{
+			// The spec declares detail as a string, so the typed response
+			// is left out, but the detail object is kept.
+			name:   "validation with an object detail",
+			status: 422,
+			body:   `{"status_code":422,"error":"Unprocessable Entity","type_name":"ValidationError","message":"Invalid settings","detail":{"missing":[{"instance_url":"required"}]},"ref":"https://developers.apideck.com/errors#validationerror"}`,
+			want: APIError{
+				StatusCode: 422,
+				TypeName:   "ValidationError",
+				Message:    "Invalid settings",
+				Detail:     map[string]any{"missing": []any{map[string]any{"instance_url": "required"}}},
+				Ref:        "https://developers.apideck.com/errors#validationerror",
+			},
+			text: "vault: ValidationError (status 422): Invalid settings",
+		},
+		{
+			name:   "not JSON",
+			status: 502,
+			body:   `Bad Gateway`,
+			want:   APIError{StatusCode: 502},
+			text:   "vault: API error (status 502): Bad Gateway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newAPIError(tt.status, []byte(tt.body))
+			if string(got.Body) != tt.body {
+				t.Errorf("Body = %s, want %s", got.Body, tt.body)
+			}
+			if !reflect.DeepEqual(got.Response, tt.response) {
+				t.Errorf("Response = %#v, want %#v", got.Response, tt.response)
+			}
+			got.Body, got.Response = nil, nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("newAPIError = %#v, want %#v", *got, tt.want)
+			}
+			if got := newAPIError(tt.status, []byte(tt.body)).Error(); got != tt.text {
+				t.Errorf("Error() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
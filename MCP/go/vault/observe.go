@@ -0,0 +1,63 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a single request the Client sent to Vault.
+type RequestInfo struct {
+	Method     string
+	Path       string // path template, e.g. /vault/consumers/{consumer_id}
+	Attempt    int    // 0 for the first attempt, n for the n-th retry
+	Duration   time.Duration
+	StatusCode int   // 0 when no response was received
+	Err        error // the transport error, or an *APIError for error responses
+}
+
+// Observer is told about every request sent with a context returned by
+// WithObserver, e.g. to record metrics.
+type Observer func(RequestInfo)
+
+type observerKey struct{}
+
+// WithObserver returns a context whose requests are reported to obs, in
+// addition to any observer ctx already carries.
+func WithObserver(ctx context.Context, obs Observer) context.Context {
+	if prev := observerFromContext(ctx); prev != nil {
+		next := obs
+		obs = func(info RequestInfo) {
+			prev(info)
+			next(info)
+		}
+	}
+	return context.WithValue(ctx, observerKey{}, obs)
+}
+
+func observerFromContext(ctx context.Context) Observer {
+	obs, _ := ctx.Value(observerKey{}).(Observer)
+	return obs
+}
+
+// observe reports an attempt of r to the observer of ctx, if any.
+func observe(ctx context.Context, r request, attempt int, start time.Time, resp *http.Response, body []byte, err error) {
+	obs := observerFromContext(ctx)
+	if obs == nil {
+		return
+	}
+	info := RequestInfo{
+		Method:   r.method,
+		Path:     r.path,
+		Attempt:  attempt,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		if resp.StatusCode >= 400 {
+			info.Err = newAPIError(resp.StatusCode, body)
+		}
+	}
+	obs(info)
+}
@@ -0,0 +1,46 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vault-api/mcp-server/config"
+)
+
+func TestObserverSeesEveryAttempt(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status_code":404,"error":"Not Found","type_name":"EntityNotFoundError","message":"Unknown consumer"}`))
+	}))
+	defer srv.Close()
+
+	var seen []RequestInfo
+	ctx := WithObserver(context.Background(), func(info RequestInfo) { seen = append(seen, info) })
+	client := NewClient(&config.APIConfig{BaseURL: srv.URL, MaxRetries: 1, RetryBaseDelay: time.Millisecond})
+	if _, err := client.ConsumersOne(ctx, ConsumersOneParams{ConsumerID: "c1"}); err == nil {
+		t.Fatal("ConsumersOne: want error")
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("observed %d requests, want 2", len(seen))
+	}
+	for i, info := range seen {
+		if info.Method != http.MethodGet || info.Path != "/vault/consumers/{consumer_id}" || info.Attempt != i {
+			t.Errorf("request %d = %+v, want GET /vault/consumers/{consumer_id} attempt %d", i, info, i)
+		}
+	}
+	var apiErr *APIError
+	if !errors.As(seen[1].Err, &apiErr) || seen[1].StatusCode != 404 || apiErr.TypeName != "EntityNotFoundError" {
+		t.Errorf("second request = %+v, want a 404 EntityNotFoundError", seen[1])
+	}
+}
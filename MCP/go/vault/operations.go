@@ -0,0 +1,864 @@
+// Code generated by cmd/gen from openapi.yaml. DO NOT EDIT.
+
+package vault
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vault-api/mcp-server/models"
+)
+
+// ConnectionsAuthorizeParams holds the path, query and header parameters of ConnectionsAuthorize.
+type ConnectionsAuthorizeParams struct {
+	ServiceID     string   `json:"service_id"`     // Service ID of the resource to return
+	ApplicationID string   `json:"application_id"` // Application ID of the resource to return
+	State         string   `json:"state"`          // An opaque value the applications adds to the initial request that the authorization server includes when redirecting the back to the application. This value must be used by the application to prevent CSRF attacks.
+	RedirectURI   string   `json:"redirect_uri"`   // URL to redirect back to after authorization. When left empty the default configured redirect uri will be used.
+	Scope         []string `json:"scope"`          // One or more OAuth scopes to request from the connector. OAuth scopes control the set of resources and operations that are allowed after authorization. Refer to the connector's documentation for the available scopes.
+}
+
+// ConnectionsAuthorize calls connectionsAuthorize (Authorize).
+//
+//	GET /vault/authorize/{service_id}/{application_id}
+func (c *Client) ConnectionsAuthorize(ctx context.Context, params ConnectionsAuthorizeParams) (*Redirect, error) {
+	var out Redirect
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/authorize/{service_id}/{application_id}",
+		pathParams: map[string]string{
+			"service_id":     params.ServiceID,
+			"application_id": params.ApplicationID,
+		},
+		query: []queryParam{
+			{name: "state", style: styleForm, explode: true, value: params.State},
+			{name: "redirect_uri", style: styleForm, explode: true, value: params.RedirectURI},
+			{name: "scope", style: styleSpaceDelimited, value: params.Scope},
+		},
+		public: true,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsCallbackParams holds the path, query and header parameters of ConnectionsCallback.
+type ConnectionsCallbackParams struct {
+	State string `json:"state"` // An opaque value the applications adds to the initial request that the authorization server includes when redirecting the back to the application. This value must be used by the application to prevent CSRF attacks.
+	Code  string `json:"code"`  // An authorization code from the connector which Apideck Vault will later exchange for an access token.
+}
+
+// ConnectionsCallback calls connectionsCallback (Callback).
+//
+//	GET /vault/callback
+func (c *Client) ConnectionsCallback(ctx context.Context, params ConnectionsCallbackParams) (*Redirect, error) {
+	var out Redirect
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/callback",
+		query: []queryParam{
+			{name: "state", style: styleForm, explode: true, value: params.State},
+			{name: "code", style: styleForm, explode: true, value: params.Code},
+		},
+		public: true,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsAllParams holds the path, query and header parameters of ConnectionsAll.
+type ConnectionsAllParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	API        string `json:"api"`                   // Scope results to Unified API
+	Configured *bool  `json:"configured"`            // Scopes results to connections that have been configured or not
+}
+
+// ConnectionsAll calls connectionsAll (Get all connections).
+//
+//	GET /vault/connections
+func (c *Client) ConnectionsAll(ctx context.Context, params ConnectionsAllParams) (*models.GetConnectionsResponse, error) {
+	var out models.GetConnectionsResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections",
+		query: []queryParam{
+			{name: "api", style: styleForm, explode: true, value: params.API},
+			{name: "configured", style: styleForm, explode: true, value: params.Configured},
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsDeleteParams holds the path, query and header parameters of ConnectionsDelete.
+type ConnectionsDeleteParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsDelete calls connectionsDelete (Deletes a connection).
+//
+//	DELETE /vault/connections/{unified_api}/{service_id}
+func (c *Client) ConnectionsDelete(ctx context.Context, params ConnectionsDeleteParams) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/vault/connections/{unified_api}/{service_id}",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, nil)
+}
+
+// ConnectionsOneParams holds the path, query and header parameters of ConnectionsOne.
+type ConnectionsOneParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsOne calls connectionsOne (Get connection).
+//
+//	GET /vault/connections/{unified_api}/{service_id}
+func (c *Client) ConnectionsOne(ctx context.Context, params ConnectionsOneParams) (*models.GetConnectionResponse, error) {
+	var out models.GetConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections/{unified_api}/{service_id}",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsUpdateParams holds the path, query and header parameters of ConnectionsUpdate.
+type ConnectionsUpdateParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsUpdateBody is the PATCH request body of ConnectionsUpdate.
+// Only the fields that are set are sent, so explicit false, 0, "" and
+// null values reach Vault unchanged.
+type ConnectionsUpdateBody struct {
+	Configuration  Optional[[]map[string]interface{}] `json:"configuration,omitzero"`
+	CustomMappings Optional[[]models.CustomMapping]   `json:"custom_mappings,omitzero"` // List of custom mappings configured for this connection
+	Enabled        Optional[bool]                     `json:"enabled,omitzero"`         // Whether the connection is enabled or not. You can enable or disable a connection using the Update Connection API.
+	Metadata       Optional[map[string]interface{}]   `json:"metadata,omitzero"`        // Attach your own consumer specific metadata
+	Settings       Optional[map[string]interface{}]   `json:"settings,omitzero"`        // Connection settings. Values will persist to `form_fields` with corresponding id
+}
+
+// ConnectionsUpdate calls connectionsUpdate (Update connection).
+//
+//	PATCH /vault/connections/{unified_api}/{service_id}
+func (c *Client) ConnectionsUpdate(ctx context.Context, params ConnectionsUpdateParams, body ConnectionsUpdateBody) (*models.UpdateConnectionResponse, error) {
+	var out models.UpdateConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   "/vault/connections/{unified_api}/{service_id}",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsAddParams holds the path, query and header parameters of ConnectionsAdd.
+type ConnectionsAddParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsAdd calls connectionsAdd (Create connection).
+//
+//	POST /vault/connections/{unified_api}/{service_id}
+func (c *Client) ConnectionsAdd(ctx context.Context, params ConnectionsAddParams, body models.Connection) (*models.CreateConnectionResponse, error) {
+	var out models.CreateConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/connections/{unified_api}/{service_id}",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsImportParams holds the path, query and header parameters of ConnectionsImport.
+type ConnectionsImportParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsImport calls connectionsImport (Import connection).
+//
+//	POST /vault/connections/{unified_api}/{service_id}/import
+func (c *Client) ConnectionsImport(ctx context.Context, params ConnectionsImportParams, body models.ConnectionImportData) (*models.CreateConnectionResponse, error) {
+	var out models.CreateConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/connections/{unified_api}/{service_id}/import",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsTokenParams holds the path, query and header parameters of ConnectionsToken.
+type ConnectionsTokenParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+}
+
+// ConnectionsToken calls connectionsToken (Get Access Token).
+//
+//	POST /vault/connections/{unified_api}/{service_id}/token
+func (c *Client) ConnectionsToken(ctx context.Context, params ConnectionsTokenParams) (*models.GetConnectionResponse, error) {
+	var out models.GetConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/connections/{unified_api}/{service_id}/token",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: struct{}{},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionSettingsAllParams holds the path, query and header parameters of ConnectionSettingsAll.
+type ConnectionSettingsAllParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	Resource   string `json:"resource"`              // Name of the resource (plural)
+}
+
+// ConnectionSettingsAll calls connectionSettingsAll (Get resource settings).
+//
+//	GET /vault/connections/{unified_api}/{service_id}/{resource}/config
+func (c *Client) ConnectionSettingsAll(ctx context.Context, params ConnectionSettingsAllParams) (*models.GetConnectionResponse, error) {
+	var out models.GetConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections/{unified_api}/{service_id}/{resource}/config",
+		pathParams: map[string]string{
+			"unified_api": params.UnifiedAPI,
+			"service_id":  params.ServiceID,
+			"resource":    params.Resource,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionSettingsUpdateParams holds the path, query and header parameters of ConnectionSettingsUpdate.
+type ConnectionSettingsUpdateParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+	Resource   string `json:"resource"`              // Name of the resource (plural)
+}
+
+// ConnectionSettingsUpdateBody is the PATCH request body of ConnectionSettingsUpdate.
+// Only the fields that are set are sent, so explicit false, 0, "" and
+// null values reach Vault unchanged.
+type ConnectionSettingsUpdateBody struct {
+	Configuration  Optional[[]map[string]interface{}] `json:"configuration,omitzero"`
+	CustomMappings Optional[[]models.CustomMapping]   `json:"custom_mappings,omitzero"` // List of custom mappings configured for this connection
+	Enabled        Optional[bool]                     `json:"enabled,omitzero"`         // Whether the connection is enabled or not. You can enable or disable a connection using the Update Connection API.
+	Metadata       Optional[map[string]interface{}]   `json:"metadata,omitzero"`        // Attach your own consumer specific metadata
+	Settings       Optional[map[string]interface{}]   `json:"settings,omitzero"`        // Connection settings. Values will persist to `form_fields` with corresponding id
+}
+
+// ConnectionSettingsUpdate calls connectionSettingsUpdate (Update settings).
+//
+//	PATCH /vault/connections/{unified_api}/{service_id}/{resource}/config
+func (c *Client) ConnectionSettingsUpdate(ctx context.Context, params ConnectionSettingsUpdateParams, body ConnectionSettingsUpdateBody) (*models.UpdateConnectionResponse, error) {
+	var out models.UpdateConnectionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   "/vault/connections/{unified_api}/{service_id}/{resource}/config",
+		pathParams: map[string]string{
+			"service_id":  params.ServiceID,
+			"unified_api": params.UnifiedAPI,
+			"resource":    params.Resource,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CustomFieldsAllParams holds the path, query and header parameters of CustomFieldsAll.
+type CustomFieldsAllParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	Resource   string `json:"resource"`              // Name of the resource (plural)
+}
+
+// CustomFieldsAll calls customFieldsAll (Get resource custom fields).
+//
+//	GET /vault/connections/{unified_api}/{service_id}/{resource}/custom-fields
+func (c *Client) CustomFieldsAll(ctx context.Context, params CustomFieldsAllParams) (*models.GetCustomFieldsResponse, error) {
+	var out models.GetCustomFieldsResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections/{unified_api}/{service_id}/{resource}/custom-fields",
+		pathParams: map[string]string{
+			"unified_api": params.UnifiedAPI,
+			"service_id":  params.ServiceID,
+			"resource":    params.Resource,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsExampleParams holds the path, query and header parameters of ConnectionsExample.
+type ConnectionsExampleParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	Resource   string `json:"resource"`              // Name of the resource (plural)
+}
+
+// ConnectionsExample calls connectionsExample (Get resource example).
+//
+//	GET /vault/connections/{unified_api}/{service_id}/{resource}/example
+func (c *Client) ConnectionsExample(ctx context.Context, params ConnectionsExampleParams) (*models.GetResourceExampleResponse, error) {
+	var out models.GetResourceExampleResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections/{unified_api}/{service_id}/{resource}/example",
+		pathParams: map[string]string{
+			"unified_api": params.UnifiedAPI,
+			"service_id":  params.ServiceID,
+			"resource":    params.Resource,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsSchemaParams holds the path, query and header parameters of ConnectionsSchema.
+type ConnectionsSchemaParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI string `json:"unified_api"`           // Unified API
+	ServiceID  string `json:"service_id"`            // Service ID of the resource to return
+	Resource   string `json:"resource"`              // Name of the resource (plural)
+}
+
+// ConnectionsSchema calls connectionsSchema (Get resource schema).
+//
+//	GET /vault/connections/{unified_api}/{service_id}/{resource}/schema
+func (c *Client) ConnectionsSchema(ctx context.Context, params ConnectionsSchemaParams) (*models.GetResourceSchemaResponse, error) {
+	var out models.GetResourceSchemaResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/connections/{unified_api}/{service_id}/{resource}/schema",
+		pathParams: map[string]string{
+			"unified_api": params.UnifiedAPI,
+			"service_id":  params.ServiceID,
+			"resource":    params.Resource,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumersAllParams holds the path, query and header parameters of ConsumersAll.
+type ConsumersAllParams struct {
+	AppID  string `json:"x-apideck-app-id"` // The ID of your Unify application
+	Cursor string `json:"cursor"`           // Cursor to start from. You can find cursors for next/previous pages in the meta.cursors property of the response.
+	Limit  *int   `json:"limit"`            // Number of results to return. Minimum 1, Maximum 200, Default 20
+}
+
+// ConsumersAll calls consumersAll (Get all consumers).
+//
+//	GET /vault/consumers
+func (c *Client) ConsumersAll(ctx context.Context, params ConsumersAllParams) (*models.GetConsumersResponse, error) {
+	var out models.GetConsumersResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/consumers",
+		query: []queryParam{
+			{name: "cursor", style: styleForm, explode: true, value: params.Cursor},
+			{name: "limit", style: styleForm, explode: true, value: params.Limit},
+		},
+		appID: params.AppID,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumersAddParams holds the path, query and header parameters of ConsumersAdd.
+type ConsumersAddParams struct {
+	AppID string `json:"x-apideck-app-id"` // The ID of your Unify application
+}
+
+// ConsumersAdd calls consumersAdd (Create consumer).
+//
+//	POST /vault/consumers
+func (c *Client) ConsumersAdd(ctx context.Context, params ConsumersAddParams, body models.Consumer) (*models.CreateConsumerResponse, error) {
+	var out models.CreateConsumerResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/consumers",
+		appID:  params.AppID,
+		body:   body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumersDeleteParams holds the path, query and header parameters of ConsumersDelete.
+type ConsumersDeleteParams struct {
+	AppID      string `json:"x-apideck-app-id"` // The ID of your Unify application
+	ConsumerID string `json:"consumer_id"`      // ID of the consumer to return
+}
+
+// ConsumersDelete calls consumersDelete (Delete consumer).
+//
+//	DELETE /vault/consumers/{consumer_id}
+func (c *Client) ConsumersDelete(ctx context.Context, params ConsumersDeleteParams) (*models.DeleteConsumerResponse, error) {
+	var out models.DeleteConsumerResponse
+	err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/vault/consumers/{consumer_id}",
+		pathParams: map[string]string{
+			"consumer_id": params.ConsumerID,
+		},
+		appID: params.AppID,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumersOneParams holds the path, query and header parameters of ConsumersOne.
+type ConsumersOneParams struct {
+	AppID      string `json:"x-apideck-app-id"` // The ID of your Unify application
+	ConsumerID string `json:"consumer_id"`      // ID of the consumer to return
+}
+
+// ConsumersOne calls consumersOne (Get consumer).
+//
+//	GET /vault/consumers/{consumer_id}
+func (c *Client) ConsumersOne(ctx context.Context, params ConsumersOneParams) (*models.GetConsumerResponse, error) {
+	var out models.GetConsumerResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/consumers/{consumer_id}",
+		pathParams: map[string]string{
+			"consumer_id": params.ConsumerID,
+		},
+		appID: params.AppID,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumersUpdateParams holds the path, query and header parameters of ConsumersUpdate.
+type ConsumersUpdateParams struct {
+	AppID      string `json:"x-apideck-app-id"` // The ID of your Unify application
+	ConsumerID string `json:"consumer_id"`      // ID of the consumer to return
+}
+
+// ConsumersUpdateBody is the PATCH request body of ConsumersUpdate.
+// Only the fields that are set are sent, so explicit false, 0, "" and
+// null values reach Vault unchanged.
+type ConsumersUpdateBody struct {
+	Metadata Optional[map[string]interface{}] `json:"metadata,omitzero"` // The metadata of the consumer. This is used to display the consumer in the sidebar. This is optional, but recommended.
+}
+
+// ConsumersUpdate calls consumersUpdate (Update consumer).
+//
+//	PATCH /vault/consumers/{consumer_id}
+func (c *Client) ConsumersUpdate(ctx context.Context, params ConsumersUpdateParams, body ConsumersUpdateBody) (*models.UpdateConsumerResponse, error) {
+	var out models.UpdateConsumerResponse
+	err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   "/vault/consumers/{consumer_id}",
+		pathParams: map[string]string{
+			"consumer_id": params.ConsumerID,
+		},
+		appID: params.AppID,
+		body:  body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConsumerRequestCountsAllParams holds the path, query and header parameters of ConsumerRequestCountsAll.
+type ConsumerRequestCountsAllParams struct {
+	AppID         string `json:"x-apideck-app-id"` // The ID of your Unify application
+	ConsumerID    string `json:"consumer_id"`      // ID of the consumer to return
+	StartDatetime string `json:"start_datetime"`   // Scopes results to requests that happened after datetime
+	EndDatetime   string `json:"end_datetime"`     // Scopes results to requests that happened before datetime
+}
+
+// ConsumerRequestCountsAll calls consumerRequestCountsAll (Consumer request counts).
+//
+//	GET /vault/consumers/{consumer_id}/stats
+func (c *Client) ConsumerRequestCountsAll(ctx context.Context, params ConsumerRequestCountsAllParams) (*models.ConsumerRequestCountsInDateRangeResponse, error) {
+	var out models.ConsumerRequestCountsInDateRangeResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/consumers/{consumer_id}/stats",
+		pathParams: map[string]string{
+			"consumer_id": params.ConsumerID,
+		},
+		query: []queryParam{
+			{name: "start_datetime", style: styleForm, explode: true, value: params.StartDatetime},
+			{name: "end_datetime", style: styleForm, explode: true, value: params.EndDatetime},
+		},
+		appID: params.AppID,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CustomMappingsDeleteParams holds the path, query and header parameters of CustomMappingsDelete.
+type CustomMappingsDeleteParams struct {
+	ConsumerID    string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID         string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI    string `json:"unified_api"`           // Unified API
+	ServiceID     string `json:"service_id"`            // Service ID of the resource to return
+	TargetFieldID string `json:"target_field_id"`       // ID of the target field to return as a custom mapping.
+}
+
+// CustomMappingsDelete calls customMappingsDelete (Deletes a custom mapping).
+//
+//	DELETE /vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}
+func (c *Client) CustomMappingsDelete(ctx context.Context, params CustomMappingsDeleteParams) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}",
+		pathParams: map[string]string{
+			"unified_api":     params.UnifiedAPI,
+			"service_id":      params.ServiceID,
+			"target_field_id": params.TargetFieldID,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, nil)
+}
+
+// CustomMappingsOneParams holds the path, query and header parameters of CustomMappingsOne.
+type CustomMappingsOneParams struct {
+	ConsumerID    string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID         string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI    string `json:"unified_api"`           // Unified API
+	ServiceID     string `json:"service_id"`            // Service ID of the resource to return
+	TargetFieldID string `json:"target_field_id"`       // ID of the target field to return as a custom mapping.
+}
+
+// CustomMappingsOne calls customMappingsOne (Get custom mapping).
+//
+//	GET /vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}
+func (c *Client) CustomMappingsOne(ctx context.Context, params CustomMappingsOneParams) (*models.GetCustomMappingResponse, error) {
+	var out models.GetCustomMappingResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}",
+		pathParams: map[string]string{
+			"unified_api":     params.UnifiedAPI,
+			"service_id":      params.ServiceID,
+			"target_field_id": params.TargetFieldID,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CustomMappingsUpdateParams holds the path, query and header parameters of CustomMappingsUpdate.
+type CustomMappingsUpdateParams struct {
+	ConsumerID    string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID         string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI    string `json:"unified_api"`           // Unified API
+	ServiceID     string `json:"service_id"`            // Service ID of the resource to return
+	TargetFieldID string `json:"target_field_id"`       // ID of the target field to return as a custom mapping.
+}
+
+// CustomMappingsUpdateBody is the PATCH request body of CustomMappingsUpdate.
+// Only the fields that are set are sent, so explicit false, 0, "" and
+// null values reach Vault unchanged.
+type CustomMappingsUpdateBody struct {
+	Value Optional[string] `json:"value,omitzero"` // Target Field Mapping value
+}
+
+// CustomMappingsUpdate calls customMappingsUpdate (Update custom mapping).
+//
+//	PATCH /vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}
+func (c *Client) CustomMappingsUpdate(ctx context.Context, params CustomMappingsUpdateParams, body CustomMappingsUpdateBody) (*models.UpdateCustomMappingResponse, error) {
+	var out models.UpdateCustomMappingResponse
+	err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   "/vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}",
+		pathParams: map[string]string{
+			"unified_api":     params.UnifiedAPI,
+			"service_id":      params.ServiceID,
+			"target_field_id": params.TargetFieldID,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CustomMappingsAddParams holds the path, query and header parameters of CustomMappingsAdd.
+type CustomMappingsAddParams struct {
+	ConsumerID    string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID         string `json:"x-apideck-app-id"`      // The ID of your Unify application
+	UnifiedAPI    string `json:"unified_api"`           // Unified API
+	ServiceID     string `json:"service_id"`            // Service ID of the resource to return
+	TargetFieldID string `json:"target_field_id"`       // ID of the target field to return as a custom mapping.
+}
+
+// CustomMappingsAdd calls customMappingsAdd (Create custom mapping).
+//
+//	POST /vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}
+func (c *Client) CustomMappingsAdd(ctx context.Context, params CustomMappingsAddParams, body models.CreateCustomMappingRequest) (*models.CreateCustomMappingResponse, error) {
+	var out models.CreateCustomMappingResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/custom-mappings/{unified_api}/{service_id}/{target_field_id}",
+		pathParams: map[string]string{
+			"unified_api":     params.UnifiedAPI,
+			"service_id":      params.ServiceID,
+			"target_field_id": params.TargetFieldID,
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// LogsAllParams holds the path, query and header parameters of LogsAll.
+type LogsAllParams struct {
+	AppID      string             `json:"x-apideck-app-id"`      // The ID of your Unify application
+	ConsumerID string             `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	Filter     *models.LogsFilter `json:"filter"`                // Filter results
+	Cursor     string             `json:"cursor"`                // Cursor to start from. You can find cursors for next/previous pages in the meta.cursors property of the response.
+	Limit      *int               `json:"limit"`                 // Number of results to return. Minimum 1, Maximum 200, Default 20
+}
+
+// LogsAll calls logsAll (Get all consumer request logs).
+//
+//	GET /vault/logs
+func (c *Client) LogsAll(ctx context.Context, params LogsAllParams) (*models.GetLogsResponse, error) {
+	var out models.GetLogsResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/logs",
+		query: []queryParam{
+			{name: "filter", style: styleDeepObject, explode: true, value: params.Filter},
+			{name: "cursor", style: styleForm, explode: true, value: params.Cursor},
+			{name: "limit", style: styleForm, explode: true, value: params.Limit},
+		},
+		appID: params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectionsRevokeParams holds the path, query and header parameters of ConnectionsRevoke.
+type ConnectionsRevokeParams struct {
+	ServiceID     string `json:"service_id"`     // Service ID of the resource to return
+	ApplicationID string `json:"application_id"` // Application ID of the resource to return
+	State         string `json:"state"`          // An opaque value the applications adds to the initial request that the authorization server includes when redirecting the back to the application. This value must be used by the application to prevent CSRF attacks.
+	RedirectURI   string `json:"redirect_uri"`   // URL to redirect back to after authorization. When left empty the default configured redirect uri will be used.
+}
+
+// ConnectionsRevoke calls connectionsRevoke (Revoke connection).
+//
+//	GET /vault/revoke/{service_id}/{application_id}
+func (c *Client) ConnectionsRevoke(ctx context.Context, params ConnectionsRevokeParams) (*Redirect, error) {
+	var out Redirect
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/vault/revoke/{service_id}/{application_id}",
+		pathParams: map[string]string{
+			"service_id":     params.ServiceID,
+			"application_id": params.ApplicationID,
+		},
+		query: []queryParam{
+			{name: "state", style: styleForm, explode: true, value: params.State},
+			{name: "redirect_uri", style: styleForm, explode: true, value: params.RedirectURI},
+		},
+		public: true,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SessionsCreateParams holds the path, query and header parameters of SessionsCreate.
+type SessionsCreateParams struct {
+	ConsumerID string `json:"x-apideck-consumer-id"` // ID of the consumer which you want to get or push data from
+	AppID      string `json:"x-apideck-app-id"`      // The ID of your Unify application
+}
+
+// SessionsCreate calls sessionsCreate (Create Session).
+//
+//	POST /vault/sessions
+func (c *Client) SessionsCreate(ctx context.Context, params SessionsCreateParams, body models.Session) (*models.CreateSessionResponse, error) {
+	var out models.CreateSessionResponse
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/vault/sessions",
+		appID:  params.AppID,
+		header: map[string]string{
+			"x-apideck-consumer-id": params.ConsumerID,
+		},
+		body: body,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
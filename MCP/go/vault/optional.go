@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a field of a PATCH request body. It tells a field that was not
+// supplied, and is left out of the body, apart from an explicit value, so
+// that false, 0, "" and null reach Vault unchanged.
+//
+// Decoding JSON into an Optional marks it as set, including for null.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Null returns an Optional that is sent as JSON null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// IsZero reports whether the field was not supplied. Together with the
+// omitzero option it leaves the field out of the request body.
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
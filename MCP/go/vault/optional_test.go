@@ -0,0 +1,55 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPatchBodySendsOnlySuppliedFields(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{
+			name: "explicit false",
+			args: `{"service_id":"pipedrive","unified_api":"crm","enabled":false}`,
+			want: `{"enabled":false}`,
+		},
+		{
+			name: "explicit null and empty object",
+			args: `{"metadata":null,"settings":{}}`,
+			want: `{"metadata":null,"settings":{}}`,
+		},
+		{
+			name: "nothing supplied",
+			args: `{"name":"read-only fields are not part of the body"}`,
+			want: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body ConnectionsUpdateBody
+			if err := json.Unmarshal([]byte(tt.args), &body); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			got, err := json.Marshal(body)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionalEmptyString(t *testing.T) {
+	got, err := json.Marshal(CustomMappingsUpdateBody{Value: Some("")})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"value":""}`; string(got) != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paramStyle is the serialization style of a query parameter, as declared by
+// the "style" keyword in openapi.yaml.
+type paramStyle int
+
+const (
+	styleForm paramStyle = iota
+	styleSpaceDelimited
+	stylePipeDelimited
+	styleDeepObject
+)
+
+// queryParam is a query parameter of an operation together with the way the
+// spec says it has to be serialized.
+type queryParam struct {
+	name    string
+	style   paramStyle
+	explode bool
+	value   any
+}
+
+// encodeQuery serializes params in order following the OpenAPI style rules:
+//
+//	form, explode:      scope=a&scope=b, status_code=201
+//	form:               scope=a,b
+//	spaceDelimited:     scope=a%20b
+//	pipeDelimited:      scope=a|b
+//	deepObject:         filter[connector_id]=crm%2Bsalesforce&filter[status_code]=201
+//
+// Unset values (nil, empty strings and empty arrays or objects) are skipped.
+func encodeQuery(params []queryParam) (string, error) {
+	var parts []string
+	for _, p := range params {
+		value, err := normalize(p.value)
+		if err != nil {
+			return "", fmt.Errorf("vault: encoding query parameter %s: %w", p.name, err)
+		}
+		if isUnset(value) {
+			continue
+		}
+		encoded, err := encodeParam(p, value)
+		if err != nil {
+			return "", fmt.Errorf("vault: encoding query parameter %s: %w", p.name, err)
+		}
+		parts = append(parts, encoded...)
+	}
+	return strings.Join(parts, "&"), nil
+}
+
+func encodeParam(p queryParam, value any) ([]string, error) {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := scalar(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, s)
+		}
+		switch {
+		case p.style == styleSpaceDelimited:
+			return []string{pair(p.name, strings.Join(items, " "))}, nil
+		case p.style == stylePipeDelimited:
+			return []string{pair(p.name, strings.Join(items, "|"))}, nil
+		case p.style == styleForm && !p.explode:
+			return []string{pair(p.name, strings.Join(items, ","))}, nil
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, pair(p.name, item))
+		}
+		return parts, nil
+	case map[string]any:
+		if p.style == styleDeepObject {
+			return deepObject(p.name, v)
+		}
+		if p.style == styleForm && p.explode {
+			var parts []string
+			for _, key := range sortedKeys(v) {
+				if isUnset(v[key]) {
+					continue
+				}
+				s, err := scalar(v[key])
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, pair(key, s))
+			}
+			return parts, nil
+		}
+		return nil, fmt.Errorf("objects are only supported with style deepObject or exploded form")
+	default:
+		s, err := scalar(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{pair(p.name, s)}, nil
+	}
+}
+
+// deepObject serializes an object as prefix[key]=value pairs, in key order,
+// descending into nested objects as prefix[key][nested]=value.
+func deepObject(prefix string, object map[string]any) ([]string, error) {
+	var parts []string
+	for _, key := range sortedKeys(object) {
+		value := object[key]
+		if isUnset(value) {
+			continue
+		}
+		name := prefix + "[" + key + "]"
+		switch v := value.(type) {
+		case map[string]any:
+			nested, err := deepObject(name, v)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, nested...)
+		case []any:
+			for _, item := range v {
+				s, err := scalar(item)
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, pair(name, s))
+			}
+		default:
+			s, err := scalar(v)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, pair(name, s))
+		}
+	}
+	return parts, nil
+}
+
+// normalize converts a Go value into its JSON data model (string, float64,
+// bool, []any, map[string]any or nil), so that struct fields are named and
+// omitted the way their JSON tags say.
+func normalize(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, bool, float64:
+		return v, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func isUnset(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func scalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pair returns name=value with the value percent-encoded. Brackets in
+// deepObject names are kept as is, spaces are encoded as %20.
+func pair(name, value string) string {
+	return name + "=" + strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// expandPath substitutes the path parameters of template, escaping each value
+// as a single path segment.
+func expandPath(template string, params map[string]string) (string, error) {
+	path := template
+	for name, value := range params {
+		if value == "" {
+			return "", fmt.Errorf("vault: missing required path parameter: %s", name)
+		}
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+	return path, nil
+}
@@ -0,0 +1,130 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	limit := 20
+	configured := false
+
+	tests := []struct {
+		name   string
+		params []queryParam
+		want   string
+	}{
+		{
+			name: "deepObject LogsFilter",
+			params: []queryParam{
+				{name: "filter", style: styleDeepObject, explode: true, value: &models.LogsFilter{
+					Connector_id:         "crm+salesforce",
+					Exclude_unified_apis: "vault,proxy",
+					Status_code:          201,
+				}},
+			},
+			want: "filter[connector_id]=crm%2Bsalesforce&filter[exclude_unified_apis]=vault%2Cproxy&filter[status_code]=201",
+		},
+		{
+			name: "deepObject omits unset LogsFilter fields",
+			params: []queryParam{
+				{name: "filter", style: styleDeepObject, explode: true, value: &models.LogsFilter{Status_code: 404}},
+			},
+			want: "filter[status_code]=404",
+		},
+		{
+			name: "nil LogsFilter",
+			params: []queryParam{
+				{name: "filter", style: styleDeepObject, explode: true, value: (*models.LogsFilter)(nil)},
+				{name: "limit", style: styleForm, explode: true, value: &limit},
+			},
+			want: "limit=20",
+		},
+		{
+			name: "spaceDelimited array",
+			params: []queryParam{
+				{name: "scope", style: styleSpaceDelimited, value: []string{"openid", "leads:write", "profile:read"}},
+			},
+			want: "scope=openid%20leads%3Awrite%20profile%3Aread",
+		},
+		{
+			name: "exploded form array",
+			params: []queryParam{
+				{name: "scope", style: styleForm, explode: true, value: []string{"a", "b"}},
+			},
+			want: "scope=a&scope=b",
+		},
+		{
+			name: "form array",
+			params: []queryParam{
+				{name: "scope", style: styleForm, value: []string{"a", "b"}},
+			},
+			want: "scope=a%2Cb",
+		},
+		{
+			name: "form scalars are escaped and unset values skipped",
+			params: []queryParam{
+				{name: "state", style: styleForm, explode: true, value: "a&b=c"},
+				{name: "redirect_uri", style: styleForm, explode: true, value: "https://example.com/cb?x=1"},
+				{name: "api", style: styleForm, explode: true, value: ""},
+				{name: "configured", style: styleForm, explode: true, value: &configured},
+				{name: "cursor", style: styleForm, explode: true, value: (*int)(nil)},
+			},
+			want: "state=a%26b%3Dc&redirect_uri=https%3A%2F%2Fexample.com%2Fcb%3Fx%3D1&configured=false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeQuery(tt.params)
+			if err != nil {
+				t.Fatalf("encodeQuery: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("encodeQuery =\n  %s\nwant\n  %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	got, err := expandPath("/vault/consumers/{consumer_id}", map[string]string{"consumer_id": "account:12345/a b"})
+	if err != nil {
+		t.Fatalf("expandPath: %v", err)
+	}
+	if want := "/vault/consumers/account:12345%2Fa%20b"; got != want {
+		t.Errorf("expandPath = %s, want %s", got, want)
+	}
+
+	if _, err := expandPath("/vault/consumers/{consumer_id}", map[string]string{"consumer_id": ""}); err == nil {
+		t.Error("expandPath with an empty parameter: want error")
+	}
+}
+
+func TestLogsAllSendsDeepObjectFilter(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":[]}`))
+	}))
+	defer srv.Close()
+
+	limit := 5
+	client := NewClient(&config.APIConfig{BaseURL: srv.URL})
+	_, err := client.LogsAll(context.Background(), LogsAllParams{
+		AppID:      "app",
+		ConsumerID: "account:12345",
+		Filter:     &models.LogsFilter{Connector_id: "crm+salesforce", Status_code: 201},
+		Limit:      &limit,
+	})
+	if err != nil {
+		t.Fatalf("LogsAll: %v", err)
+	}
+	if want := "filter[connector_id]=crm%2Bsalesforce&filter[status_code]=201&limit=5"; gotQuery != want {
+		t.Errorf("query = %s, want %s", gotQuery, want)
+	}
+}
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// retryDelay decides whether the attempt that produced resp or err should be
+// retried, and how long to wait before doing so.
+//
+// Rate limited (429) and transient 5xx responses are retried, as are
+// transport errors. Only GET operations are retried, and DELETE operations
+// when Vault shows they were not executed, unless the configuration opts in
+// to retrying all operations.
+func (c *Client) retryDelay(ctx context.Context, r request, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.cfg.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+	switch {
+	case r.method == http.MethodGet || c.cfg.RetryNonIdempotent:
+	case r.method == http.MethodDelete:
+		// A DELETE that failed in transit or with a server error may have
+		// gone through, and its retry would then fail with a 404.
+		if !notExecuted(resp, err) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if err == nil && !retryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	wait := c.backoff(attempt)
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if after > c.cfg.RetryMaxDelay {
+				// Vault asks us to wait longer than we are willing to.
+				return 0, false
+			}
+			wait = max(wait, after)
+		}
+	}
+	return wait, true
+}
+
+// notExecuted reports whether resp shows that Vault did not act on the
+// request: it was rate limited, or Vault was unavailable and said when to
+// come back.
+func notExecuted(resp *http.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != ""
+}
+
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the exponential backoff with jitter before retry number
+// attempt+1: a random duration between half and all of base*2^attempt,
+// capped by the maximum delay.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.cfg.RetryBaseDelay << attempt
+	if d <= 0 || d > c.cfg.RetryMaxDelay {
+		d = c.cfg.RetryMaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, returning early with the context's error when ctx is
+// done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// CallStats counts the requests the Client sends for calls made with a
+// context returned by WithCallStats.
+type CallStats struct {
+	requests atomic.Int64
+	retries  atomic.Int64
+}
+
+// Requests returns the number of HTTP requests sent, including retries.
+func (s *CallStats) Requests() int { return int(s.requests.Load()) }
+
+// Retries returns the number of requests that were retries of a failed one.
+func (s *CallStats) Retries() int { return int(s.retries.Load()) }
+
+func (s *CallStats) addRequest() {
+	if s != nil {
+		s.requests.Add(1)
+	}
+}
+
+func (s *CallStats) addRetry() {
+	if s != nil {
+		s.retries.Add(1)
+	}
+}
+
+type callStatsKey struct{}
+
+// WithCallStats returns a context that records the requests made with it.
+func WithCallStats(ctx context.Context) (context.Context, *CallStats) {
+	stats := &CallStats{}
+	return context.WithValue(ctx, callStatsKey{}, stats), stats
+}
+
+func callStatsFromContext(ctx context.Context) *CallStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*CallStats)
+	return stats
+}
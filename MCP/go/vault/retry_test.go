@@ -0,0 +1,173 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vault-api/mcp-server/config"
+	"github.com/vault-api/mcp-server/models"
+)
+
+// failingServer answers every request with status and the given
+// Retry-After header, or closes the connection when status is 0, and counts
+// the requests in attempts.
+func failingServer(t *testing.T, status int, retryAfter string, attempts *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if status == 0 {
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.APIConfig
+		method     string
+		status     int
+		retryAfter string
+		attempts   int32
+	}{
+		{name: "5xx retried", cfg: config.APIConfig{MaxRetries: 2}, method: http.MethodGet, status: http.StatusServiceUnavailable, attempts: 3},
+		{name: "4xx not retried", cfg: config.APIConfig{MaxRetries: 2}, method: http.MethodGet, status: http.StatusNotFound, attempts: 1},
+		{name: "no retries", cfg: config.APIConfig{MaxRetries: 0}, method: http.MethodGet, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "transport error retried", cfg: config.APIConfig{MaxRetries: 2}, method: http.MethodGet, attempts: 3},
+		{name: "DELETE rate limited retried", cfg: config.APIConfig{MaxRetries: 1}, method: http.MethodDelete, status: http.StatusTooManyRequests, attempts: 2},
+		{
+			name:       "DELETE unavailable with Retry-After retried",
+			cfg:        config.APIConfig{MaxRetries: 1},
+			method:     http.MethodDelete,
+			status:     http.StatusServiceUnavailable,
+			retryAfter: "0",
+			attempts:   2,
+		},
+		{name: "DELETE unavailable not retried", cfg: config.APIConfig{MaxRetries: 1}, method: http.MethodDelete, status: http.StatusServiceUnavailable, attempts: 1},
+		{name: "DELETE 5xx not retried", cfg: config.APIConfig{MaxRetries: 1}, method: http.MethodDelete, status: http.StatusBadGateway, attempts: 1},
+		{name: "DELETE transport error not retried", cfg: config.APIConfig{MaxRetries: 1}, method: http.MethodDelete, attempts: 1},
+		{
+			name:     "DELETE retried when opted in",
+			cfg:      config.APIConfig{MaxRetries: 1, RetryNonIdempotent: true},
+			method:   http.MethodDelete,
+			status:   http.StatusBadGateway,
+			attempts: 2,
+		},
+		{name: "POST not retried", cfg: config.APIConfig{MaxRetries: 2}, method: http.MethodPost, status: http.StatusServiceUnavailable, attempts: 1},
+		{
+			name:     "POST retried when opted in",
+			cfg:      config.APIConfig{MaxRetries: 2, RetryNonIdempotent: true},
+			method:   http.MethodPost,
+			status:   http.StatusServiceUnavailable,
+			attempts: 3,
+		},
+		{
+			name:       "Retry-After beyond the maximum delay",
+			cfg:        config.APIConfig{MaxRetries: 2, RetryMaxDelay: time.Second},
+			method:     http.MethodGet,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "120",
+			attempts:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := failingServer(t, tt.status, tt.retryAfter, &attempts)
+			cfg := tt.cfg
+			cfg.BaseURL = srv.URL
+			cfg.RetryBaseDelay = time.Millisecond
+			if cfg.RetryMaxDelay == 0 {
+				cfg.RetryMaxDelay = 10 * time.Millisecond
+			}
+			client := NewClient(&cfg)
+			ctx, stats := WithCallStats(context.Background())
+
+			var err error
+			switch tt.method {
+			case http.MethodGet:
+				_, err = client.ConsumersOne(ctx, ConsumersOneParams{ConsumerID: "c1"})
+			case http.MethodDelete:
+				_, err = client.ConsumersDelete(ctx, ConsumersDeleteParams{ConsumerID: "c1"})
+			case http.MethodPost:
+				_, err = client.ConsumersAdd(ctx, ConsumersAddParams{}, models.Consumer{Consumer_id: "c1"})
+			}
+			if err == nil {
+				t.Fatal("want error")
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("sent %d requests, want %d", got, tt.attempts)
+			}
+			if stats.Requests() != int(tt.attempts) || stats.Retries() != int(tt.attempts)-1 {
+				t.Errorf("stats = %d requests, %d retries; want %d, %d", stats.Requests(), stats.Retries(), tt.attempts, tt.attempts-1)
+			}
+		})
+	}
+}
+
+func TestRetryAfterIsHonoured(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"status_code":200,"status":"OK","data":{"consumer_id":"c1"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&config.APIConfig{BaseURL: srv.URL, MaxRetries: 1, RetryBaseDelay: time.Millisecond, RetryMaxDelay: 5 * time.Second})
+	start := time.Now()
+	resp, err := client.ConsumersOne(context.Background(), ConsumersOneParams{ConsumerID: "c1"})
+	if err != nil {
+		t.Fatalf("ConsumersOne: %v", err)
+	}
+	if resp.Data.Consumer_id != "c1" || attempts.Load() != 2 {
+		t.Errorf("got consumer %q after %d requests, want c1 after 2", resp.Data.Consumer_id, attempts.Load())
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %s, want at least the 1s of Retry-After", waited)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{header: "", ok: false},
+		{header: "1", want: time.Second, ok: true},
+		{header: "0", want: 0, ok: true},
+		{header: "-5", ok: false},
+		{header: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second, ok: true},
+		{header: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, ok: true},
+		{header: "Mon, 05 Jan 2026 10:00:30 GMT", want: 30 * time.Second, ok: true},
+		{header: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %s, %t; want %s, %t", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
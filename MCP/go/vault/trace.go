@@ -0,0 +1,59 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer records a client span for every request sent to Vault. Spans are
+// only exported when the program installs a TracerProvider.
+var tracer = otel.Tracer("github.com/vault-api/mcp-server/vault")
+
+// startSpan starts the span of one attempt of r, following the OpenTelemetry
+// HTTP client conventions.
+func startSpan(ctx context.Context, r request, attempt int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", r.method),
+		attribute.String("url.template", r.path),
+	}
+	if attempt > 0 {
+		attrs = append(attrs, attribute.Int("http.request.resend_count", attempt))
+	}
+	return tracer.Start(ctx, r.method+" "+r.path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// injectTraceContext passes the trace context of ctx on to Vault in the
+// request headers, e.g. as a W3C traceparent header.
+func injectTraceContext(ctx context.Context, req *http.Request) {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("server.address", req.URL.Hostname()))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// endSpan records the outcome of an attempt and ends its span.
+func endSpan(span trace.Span, resp *http.Response, body []byte, err error) {
+	defer span.End()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		errorType := strconv.Itoa(resp.StatusCode)
+		if apiErr := newAPIError(resp.StatusCode, body); apiErr.TypeName != "" {
+			errorType = apiErr.TypeName
+		}
+		span.SetAttributes(attribute.String("error.type", errorType))
+		span.SetStatus(codes.Error, errorType)
+	}
+}